@@ -6,7 +6,9 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
 )
@@ -36,7 +38,13 @@ This allows heredocs and pipes for rich content:
   ` + "```" + `
   EOF
 
-  echo "Simple description" | todo add 'Fix bug'`,
+  echo "Simple description" | todo add 'Fix bug'
+
+Templates in templates/<name>.md in the tickets directory pre-fill the
+description, tags, priority, design, acceptance and estimate. The template named
+after the ticket type is used by default (todo add -t bug uses bug.md);
+--template picks another. Flags and a given description take precedence, and
+tags are added to --tags. Templates can use {{.Title}} and {{.Date}}.`,
 	Args: cobra.RangeArgs(0, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Title: first arg or "Untitled"
@@ -65,34 +73,97 @@ This allows heredocs and pipes for rich content:
 			}
 		}
 
-		// Get flag values
-		ticketType, _ := cmd.Flags().GetString("type")
-		priority, _ := cmd.Flags().GetInt("priority")
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		// Get flag values, falling back to configured defaults
+		ticketType := cfg.Defaults.Type
+		if cmd.Flags().Changed("type") {
+			ticketType, _ = cmd.Flags().GetString("type")
+		}
+		priority := cfg.Defaults.Priority
+		if cmd.Flags().Changed("priority") {
+			priority, _ = cmd.Flags().GetInt("priority")
+		}
 		assignee, _ := cmd.Flags().GetString("assignee")
 		externalRef, _ := cmd.Flags().GetString("external-ref")
 		parent, _ := cmd.Flags().GetString("parent")
 		design, _ := cmd.Flags().GetString("design")
 		acceptance, _ := cmd.Flags().GetString("acceptance")
 		tagsStr, _ := cmd.Flags().GetString("tags")
+		estimate, _ := cmd.Flags().GetString("estimate")
+		due, _ := cmd.Flags().GetString("due")
+		scheduled, _ := cmd.Flags().GetString("scheduled")
+		recur, _ := cmd.Flags().GetString("recur")
+
+		// Templates: --template, or the default one for the ticket type
+		var tp *tickets.Template
+		if name, _ := cmd.Flags().GetString("template"); name != "" {
+			if tp, err = tickets.LoadTemplate(dir, name); err != nil {
+				return err
+			}
+			if tp.Type != "" && !cmd.Flags().Changed("type") {
+				ticketType = tp.Type
+			}
+		} else if tp, err = tickets.FindTemplate(dir, ticketType); err != nil {
+			return err
+		}
+		if tp != nil && tp.Priority != nil && !cmd.Flags().Changed("priority") {
+			priority = *tp.Priority
+		}
 
 		// Validate type
-		validTypes := map[string]bool{
-			"bug": true, "feature": true, "task": true, "epic": true, "chore": true,
-		}
-		if !validTypes[ticketType] {
-			return fmt.Errorf("invalid type %q: must be one of bug, feature, task, epic, chore", ticketType)
+		if err := cfg.CheckType(ticketType); err != nil {
+			return err
 		}
 
 		// Validate priority
-		if priority < 0 || priority > 4 {
-			return fmt.Errorf("invalid priority %d: must be between 0 and 4", priority)
+		if err := cfg.CheckPriority(priority); err != nil {
+			return err
 		}
 
-		// Default assignee to git user.name if not set
+		// Validate estimate
+		if estimate != "" {
+			if _, err := tickets.ParseEstimate(estimate); err != nil {
+				return err
+			}
+		}
+
+		// Validate recurrence
+		if recur != "" {
+			if _, err := tickets.ParseRecurrence(recur); err != nil {
+				return err
+			}
+		}
+
+		// Parse dates
+		now := time.Now()
+		if due != "" {
+			if due, err = tickets.ParseDate(due, now); err != nil {
+				return err
+			}
+		}
+		if scheduled != "" {
+			if scheduled, err = tickets.ParseDate(scheduled, now); err != nil {
+				return err
+			}
+		}
+
+		// Default assignee to the configured assignee, then git user.name
 		if !cmd.Flags().Changed("assignee") {
-			gitName, err := exec.Command("git", "config", "user.name").Output()
-			if err == nil {
-				assignee = strings.TrimSpace(string(gitName))
+			assignee = cfg.Defaults.Assignee
+			if assignee == "" {
+				gitName, err := exec.Command("git", "config", "user.name").Output()
+				if err == nil {
+					assignee = strings.TrimSpace(string(gitName))
+				}
 			}
 		}
 
@@ -107,11 +178,6 @@ This allows heredocs and pipes for rich content:
 			}
 		}
 
-		dir, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-
 		t := &tickets.Ticket{
 			Title:       title,
 			Description: description,
@@ -122,8 +188,17 @@ This allows heredocs and pipes for rich content:
 			Parent:      parent,
 			Design:      design,
 			Acceptance:  acceptance,
+			Estimate:    estimate,
+			Due:         due,
+			Scheduled:   scheduled,
+			Recur:       recur,
 			Tags:        tags,
 		}
+		if tp != nil {
+			if err := tp.Apply(t, now); err != nil {
+				return err
+			}
+		}
 
 		ticket, err := tickets.Add(dir, t)
 		if err != nil {
@@ -140,12 +215,17 @@ func init() {
 	rootCmd.AddCommand(addCmd)
 
 	addCmd.Flags().StringP("description", "d", "", "Ticket description")
-	addCmd.Flags().StringP("type", "t", "task", "Ticket type (bug/feature/task/epic/chore)")
-	addCmd.Flags().IntP("priority", "p", 2, "Priority (0-4)")
-	addCmd.Flags().StringP("assignee", "a", "", "Assignee (defaults to git user.name)")
+	addCmd.Flags().StringP("type", "t", "task", "Ticket type (bug/feature/task/epic/chore, or as configured)")
+	addCmd.Flags().IntP("priority", "p", 2, "Priority (0-4, or as configured)")
+	addCmd.Flags().StringP("assignee", "a", "", "Assignee (defaults to configured assignee or git user.name)")
 	addCmd.Flags().String("external-ref", "", "External reference (e.g. JIRA-123)")
 	addCmd.Flags().String("parent", "", "Parent ticket ID (must exist)")
 	addCmd.Flags().String("design", "", "Design notes")
 	addCmd.Flags().String("acceptance", "", "Acceptance criteria")
 	addCmd.Flags().String("tags", "", "Comma-separated tags")
+	addCmd.Flags().String("estimate", "", "Estimated work (e.g. 4h, 1.5d, 2w)")
+	addCmd.Flags().String("template", "", "Template from the templates directory (defaults to the one named after the type)")
+	addCmd.Flags().String("due", "", "Due date (e.g. 2026-10-23, friday, +3d)")
+	addCmd.Flags().String("scheduled", "", "Date to start working (e.g. 2026-10-20, monday, +1w)")
+	addCmd.Flags().String("recur", "", "Recreate the ticket when done: daily, weekly, monthly, yearly or every 3d/2w/6m")
 }
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [id...]",
+	Short: "Move done tickets into the archive",
+	Long: `Move done tickets into the archive/ directory inside the tickets directory.
+With no arguments, every done ticket is archived. Given IDs must refer to done tickets.
+
+Archived tickets are left out of list, ready, blocked, closed, query and the TUI,
+but can still be read with show and query --archived.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		archived, err := tickets.Archive(dir, args)
+		if err != nil {
+			return err
+		}
+
+		if len(archived) == 0 {
+			fmt.Println("No tickets to archive")
+			return nil
+		}
+
+		for _, t := range archived {
+			fmt.Printf("Archived %s %s\n", cliID(t.ID), t.Title)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+}
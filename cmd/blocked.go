@@ -5,22 +5,30 @@ import (
 	"os"
 	"sort"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
 )
 
 var blockedCmd = &cobra.Command{
-	Use:   "blocked",
+	Use:   "blocked [filter...]",
 	Short: "Show tickets blocked by unclosed dependencies",
-	Long:  `Show open/in_progress tickets that have at least one unclosed dependency, sorted by priority then ID.`,
-	Args:  cobra.NoArgs,
+	Long: `Show tickets that are not done and have at least one dependency that is not done, sorted by priority then ID. Tickets can be narrowed with a filter expression.
+
+` + filterHelp + "\n\n" + formatHelp,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		allItems, err := tickets.List(dir)
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		allItems, err := listTickets(cmd, dir)
 		if err != nil {
 			return err
 		}
@@ -31,8 +39,15 @@ var blockedCmd = &cobra.Command{
 			statusMap[t.ID] = t.Status
 		}
 
-		assigneeFilter, _ := cmd.Flags().GetString("assignee")
-		tagFilter, _ := cmd.Flags().GetString("tag")
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := templateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 
 		type blockedTicket struct {
 			ticket           *tickets.Ticket
@@ -41,16 +56,16 @@ var blockedCmd = &cobra.Command{
 
 		var blocked []blockedTicket
 		for _, t := range allItems {
-			// Only open/in_progress tickets (not closed)
-			if t.Status == "closed" {
+			// Only tickets that are not done
+			if cfg.IsDone(t.Status) {
 				continue
 			}
 
-			// Find unclosed deps
+			// Find deps that are not done
 			var unclosed []string
 			for _, depID := range t.Deps {
 				depStatus, exists := statusMap[depID]
-				if exists && depStatus != "closed" {
+				if exists && !cfg.IsDone(depStatus) {
 					unclosed = append(unclosed, depID)
 				}
 				// Missing deps treated as non-blocking
@@ -61,25 +76,10 @@ var blockedCmd = &cobra.Command{
 				continue
 			}
 
-			// Apply --assignee filter
-			if assigneeFilter != "" && t.Assignee != assigneeFilter {
+			if !filter.Match(t) {
 				continue
 			}
 
-			// Apply --tag filter
-			if tagFilter != "" {
-				found := false
-				for _, tag := range t.Tags {
-					if tag == tagFilter {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
-			}
-
 			blocked = append(blocked, blockedTicket{ticket: t, unclosedBlockers: unclosed})
 		}
 
@@ -91,6 +91,23 @@ var blockedCmd = &cobra.Command{
 			return blocked[i].ticket.ID < blocked[j].ticket.ID
 		})
 
+		if tmpl != nil {
+			var items []*tickets.Ticket
+			for _, bt := range blocked {
+				items = append(items, bt.ticket)
+			}
+			return printTemplate(tmpl, items, allItems, cfg)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			for _, bt := range blocked {
+				if err := printJSON(blockedTicketJSON{queryTicket: toQueryTicket(bt.ticket), Blockers: bt.unclosedBlockers}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
 		for _, bt := range blocked {
 			fmt.Println(formatBlockedLine(bt.ticket, bt.unclosedBlockers))
 		}
@@ -102,5 +119,8 @@ var blockedCmd = &cobra.Command{
 func init() {
 	blockedCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	blockedCmd.Flags().StringP("tag", "T", "", "Filter by tag")
+	addFormatFlag(blockedCmd)
+	addJSONFlag(blockedCmd, "Output tickets as JSON Lines, with the IDs of their unclosed deps in blockers")
+	blockedCmd.MarkFlagsMutuallyExclusive("format", "json")
 	rootCmd.AddCommand(blockedCmd)
 }
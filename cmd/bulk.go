@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk [--where <filter>] <action> [args...]",
+	Short: "Apply a change to many tickets at once",
+	Long: `Apply a change to every ticket matching a filter, or to ticket IDs read from stdin.
+
+Actions:
+  close                  Set the done status
+  start                  Set status to in_progress
+  reopen                 Set status to open
+  status <status>        Set the given status
+  set <field=value>...   Change fields, like todo set
+
+The filter (--where) is a filter expression, as in todo list (see todo help list).
+Without --where, IDs are read from stdin, one per line; anything after the ID on a
+line is ignored, so the output of list, ready or blocked can be piped in. IDs of
+archived tickets are an error, reported before anything is changed.
+
+The affected tickets are previewed and a single confirmation is asked before
+anything is changed (skip it with --yes). Changes are validated for every ticket
+first: if any is invalid, no ticket is modified.
+
+  todo bulk --where 'tag=sprint-12 status=in_progress' close
+  todo bulk --where 'assignee=Alice type=bug status=open' set assignee=Bob
+  todo bulk --where 'priority>=3 (tag=ui OR tag=docs)' set priority=2
+  todo ready -T perf | todo bulk --yes set priority=1`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		changes, err := bulkChanges(cfg, args[0], args[1:])
+		if err != nil {
+			return err
+		}
+
+		where, _ := cmd.Flags().GetString("where")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		var targets []*tickets.Ticket
+		readIDsFromStdin := !cmd.Flags().Changed("where")
+		if readIDsFromStdin {
+			if term.IsTerminal(int(os.Stdin.Fd())) {
+				return fmt.Errorf("no tickets selected: use --where or pipe ticket IDs to stdin")
+			}
+			targets, err = readTicketsFromStdin(dir, os.Stdin)
+			if err != nil {
+				return err
+			}
+		} else {
+			filter, err := tickets.ParseFilter(where)
+			if err != nil {
+				return err
+			}
+			allItems, err := listTickets(cmd, dir)
+			if err != nil {
+				return err
+			}
+			targets = tickets.FilterTickets(allItems, filter)
+		}
+
+		if len(targets) == 0 {
+			fmt.Println("No tickets match")
+			return nil
+		}
+
+		// Preview
+		for _, t := range targets {
+			fmt.Println(formatTicketLine(t))
+		}
+
+		action := strings.Join(args, " ")
+		if dryRun {
+			fmt.Printf("Would apply '%s' to %d ticket(s)\n", action, len(targets))
+			return nil
+		}
+
+		if !yes {
+			ok, err := confirm(fmt.Sprintf("Apply '%s' to %d ticket(s)? [y/N] ", action, len(targets)), readIDsFromStdin)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		var ids []string
+		for _, t := range targets {
+			ids = append(ids, t.ID)
+		}
+
+		updated, created, err := tickets.Set(dir, ids, changes)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range updated {
+			fmt.Printf("Updated %s %s\n", cliID(t.ID), t.Title)
+		}
+		for _, next := range created {
+			printNextOccurrence(next)
+		}
+		if len(updated) == 0 {
+			fmt.Println("No changes")
+		}
+
+		return nil
+	},
+}
+
+// bulkChanges translates a bulk action and its arguments into field changes.
+func bulkChanges(cfg *config.Config, action string, args []string) ([]tickets.FieldChange, error) {
+	status := func(s string) []tickets.FieldChange {
+		return []tickets.FieldChange{{Field: "status", Op: tickets.OpSet, Value: s}}
+	}
+
+	// Shortcuts for status changes, like the close, start and reopen commands
+	shortcuts := map[string]string{
+		"close":  cfg.DoneStatus(),
+		"start":  "in_progress",
+		"reopen": "open",
+	}
+	if s, ok := shortcuts[action]; ok {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%s takes no arguments", action)
+		}
+		return status(s), nil
+	}
+
+	switch action {
+	case "status":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("status takes exactly one argument")
+		}
+		return status(args[0]), nil
+	case "set":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("set needs at least one field=value assignment")
+		}
+		var changes []tickets.FieldChange
+		for _, arg := range args {
+			c, err := tickets.ParseFieldChange(arg)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, c)
+		}
+		return changes, nil
+	default:
+		return nil, fmt.Errorf("unknown action %q: must be one of close, start, reopen, status, set", action)
+	}
+}
+
+// readTicketsFromStdin reads one ticket ID per line (the first word of each line)
+// and resolves them to tickets. Blank lines are ignored.
+func readTicketsFromStdin(dir string, r io.Reader) ([]*tickets.Ticket, error) {
+	var result []*tickets.Ticket
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		t, err := tickets.Get(dir, fields[0])
+		if err != nil {
+			return nil, err
+		}
+		if !seen[t.ID] {
+			seen[t.ID] = true
+			result = append(result, t)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+
+	return result, nil
+}
+
+// confirm asks a yes/no question. The answer is read from stdin, or from the
+// terminal when stdin is already used for input.
+func confirm(prompt string, stdinInUse bool) (bool, error) {
+	in := os.Stdin
+	if stdinInUse {
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return false, fmt.Errorf("cannot ask for confirmation without a terminal: use --yes")
+		}
+		defer tty.Close()
+		in = tty
+	}
+
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+func init() {
+	bulkCmd.Flags().StringP("where", "w", "", "Filter expression selecting the tickets (e.g. 'tag=sprint-12 status=open')")
+	bulkCmd.Flags().BoolP("yes", "y", false, "Apply without asking for confirmation")
+	bulkCmd.Flags().Bool("dry-run", false, "Only preview the affected tickets")
+	rootCmd.AddCommand(bulkCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check tickets for broken references and inconsistencies",
+	Long: `Check the tickets directory for integrity problems:
+
+  - files that can't be parsed
+  - frontmatter id that doesn't match the filename
+  - duplicate ids
+  - deps, links and parents pointing at tickets that don't exist
+  - links that are only present on one side
+  - dependency cycles among tickets that are not done
+
+With --fix, repairs what can be repaired mechanically: sets the frontmatter id to
+the filename, removes dangling deps, links and parents, and adds missing back-links.
+Unparseable files and cycles are left for you to resolve.
+
+With --strict, exits with a non-zero status when problems remain (for CI).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		fix, _ := cmd.Flags().GetBool("fix")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		if fix {
+			fixed, err := tickets.Fix(dir)
+			if err != nil {
+				return err
+			}
+			for _, p := range fixed {
+				fmt.Printf("Fixed %s\n", p)
+			}
+		}
+
+		problems, err := tickets.Check(dir)
+		if err != nil {
+			return err
+		}
+
+		fixable := 0
+		for _, p := range problems {
+			fmt.Println(p)
+			if p.Fixable() {
+				fixable++
+			}
+		}
+
+		if len(problems) == 0 {
+			fmt.Println("No problems found")
+			return nil
+		}
+
+		summary := fmt.Sprintf("%d problem(s) found", len(problems))
+		if fixable > 0 {
+			summary += fmt.Sprintf(", %d fixable with --fix", fixable)
+		}
+		if strict {
+			return fmt.Errorf("%s", summary)
+		}
+		fmt.Println(summary)
+
+		return nil
+	},
+}
+
+func init() {
+	checkCmd.Flags().Bool("fix", false, "Repair problems that can be fixed mechanically")
+	checkCmd.Flags().Bool("strict", false, "Exit with a non-zero status if any problem remains")
+	rootCmd.AddCommand(checkCmd)
+}
@@ -11,8 +11,9 @@ import (
 var closeCmd = &cobra.Command{
 	Use:   "close <id>",
 	Short: "Close a ticket (set status to closed)",
-	Long:  `Set a ticket's status to closed.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Set a ticket's status to closed, or the first configured done status.
+A recurring ticket gets a linked next occurrence, like with done.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
@@ -21,12 +22,13 @@ var closeCmd = &cobra.Command{
 			return err
 		}
 
-		title, err := tickets.SetStatus(dir, id, "closed")
+		title, next, err := tickets.Done(dir, id)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Closed ticket: %s\n", title)
+		printNextOccurrence(next)
 
 		return nil
 	},
@@ -3,86 +3,78 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-	"sort"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
 )
 
 var closedCmd = &cobra.Command{
-	Use:   "closed",
+	Use:   "closed [filter...]",
 	Short: "Show recently closed tickets",
-	Long:  `Show closed tickets sorted by file modification time (most recent first), with an optional limit.`,
-	Args:  cobra.NoArgs,
+	Long: `Show closed tickets sorted by close time (most recent first), with an optional limit. Tickets can be narrowed with a filter expression.
+
+` + filterHelp + "\n\n" + formatHelp,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		allItems, err := tickets.List(dir)
+		cfg, err := config.Load(dir)
 		if err != nil {
 			return err
 		}
 
-		assigneeFilter, _ := cmd.Flags().GetString("assignee")
-		tagFilter, _ := cmd.Flags().GetString("tag")
-		limit, _ := cmd.Flags().GetInt("limit")
+		allItems, err := listTickets(cmd, dir)
+		if err != nil {
+			return err
+		}
 
-		type closedTicket struct {
-			ticket *tickets.Ticket
-			mtime  int64
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := templateFromFlags(cmd)
+		if err != nil {
+			return err
 		}
+		limit, _ := cmd.Flags().GetInt("limit")
 
-		var closed []closedTicket
+		var closed []*tickets.Ticket
 		for _, t := range allItems {
-			// Only closed tickets
-			if t.Status != "closed" {
+			// Only done tickets
+			if !cfg.IsDone(t.Status) {
 				continue
 			}
 
-			// Apply --assignee filter
-			if assigneeFilter != "" && t.Assignee != assigneeFilter {
+			if !filter.Match(t) {
 				continue
 			}
 
-			// Apply --tag filter
-			if tagFilter != "" {
-				found := false
-				for _, tag := range t.Tags {
-					if tag == tagFilter {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
-			}
-
-			// Stat the file for mtime
-			path := filepath.Join(tickets.DirPath(dir), t.ID+".md")
-			info, err := os.Stat(path)
-			if err != nil {
-				continue // skip if stat fails
-			}
-
-			closed = append(closed, closedTicket{ticket: t, mtime: info.ModTime().UnixNano()})
+			closed = append(closed, t)
 		}
 
-		// Sort by mtime descending (most recently modified first)
-		sort.Slice(closed, func(i, j int) bool {
-			return closed[i].mtime > closed[j].mtime
-		})
+		// Sort by close time descending (most recently closed first)
+		tickets.SortRecentlyClosed(closed)
 
 		// Apply limit
 		if limit > 0 && len(closed) > limit {
 			closed = closed[:limit]
 		}
 
-		for _, ct := range closed {
-			fmt.Println(formatClosedLine(ct.ticket))
+		if tmpl != nil {
+			return printTemplate(tmpl, closed, allItems, cfg)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSONLines(closed)
+		}
+
+		for _, t := range closed {
+			fmt.Println(formatClosedLine(t))
 		}
 
 		return nil
@@ -93,5 +85,8 @@ func init() {
 	closedCmd.Flags().IntP("limit", "n", 20, "Maximum number of tickets to show")
 	closedCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	closedCmd.Flags().StringP("tag", "T", "", "Filter by tag")
+	addFormatFlag(closedCmd)
+	addJSONFlag(closedCmd, "Output tickets as JSON Lines, like todo query")
+	closedCmd.MarkFlagsMutuallyExclusive("format", "json")
 	rootCmd.AddCommand(closedCmd)
 }
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"golang.org/x/term"
+)
+
+// ticketColumns maps each column name to its header and cell value.
+var ticketColumns = map[string]struct {
+	header string
+	value  func(t *tickets.Ticket) string
+}{
+	"id":       {"ID", func(t *tickets.Ticket) string { return t.ID }},
+	"priority": {"PRI", func(t *tickets.Ticket) string { return fmt.Sprintf("P%d", t.Priority) }},
+	"status": {"STATUS", func(t *tickets.Ticket) string {
+		if t.Status == "" {
+			return "open"
+		}
+		return t.Status
+	}},
+	"type":     {"TYPE", func(t *tickets.Ticket) string { return t.Type }},
+	"assignee": {"ASSIGNEE", func(t *tickets.Ticket) string { return t.Assignee }},
+	"tags":     {"TAGS", func(t *tickets.Ticket) string { return strings.Join(t.Tags, ",") }},
+	"parent":   {"PARENT", func(t *tickets.Ticket) string { return t.Parent }},
+	"deps":     {"DEPS", func(t *tickets.Ticket) string { return strings.Join(t.Deps, ",") }},
+	"created":  {"CREATED", func(t *tickets.Ticket) string { return dateOnly(t.Created) }},
+	"updated":  {"UPDATED", func(t *tickets.Ticket) string { return dateOnly(t.Updated) }},
+	"title":    {"TITLE", func(t *tickets.Ticket) string { return t.Title }},
+}
+
+// defaultColumns are the columns todo list shows on a terminal without --columns.
+var defaultColumns = []string{"id", "priority", "status", "type", "assignee", "tags", "title"}
+
+// columnNames lists the valid column names in display order, for error messages.
+var columnNames = []string{"id", "priority", "status", "type", "assignee", "tags", "parent", "deps", "created", "updated", "title"}
+
+// dateOnly shortens an RFC3339 timestamp to its date.
+func dateOnly(ts string) string {
+	if len(ts) >= len("2006-01-02") {
+		return ts[:len("2006-01-02")]
+	}
+	return ts
+}
+
+// parseColumns parses a comma-separated column list like "id,priority,title".
+func parseColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := ticketColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q: must be one of %s", name, strings.Join(columnNames, ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// formatTicketTable renders tickets with the given columns. On a terminal the
+// columns are aligned under a header; otherwise cells are tab-separated so the
+// output stays easy to process with cut or awk.
+func formatTicketTable(items []*tickets.Ticket, columns []string) string {
+	rows := make([][]string, len(items))
+	for i, t := range items {
+		for _, c := range columns {
+			rows[i] = append(rows[i], ticketColumns[c].value(t))
+		}
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		var b strings.Builder
+		for _, row := range rows {
+			b.WriteString(strings.Join(row, "\t"))
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	headers := make([]string, len(columns))
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		headers[i] = ticketColumns[c].header
+		widths[i] = lipgloss.Width(headers[i])
+		for _, row := range rows {
+			if w := lipgloss.Width(row[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string, style func(col int, s string) string) {
+		for i, cell := range cells {
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			styled := style(i, cell)
+			b.WriteString(styled)
+			// No trailing padding on the last column
+			if i < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(cell)))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(headers, func(_ int, s string) string { return cliHeader.Render(s) })
+	for _, row := range rows {
+		writeRow(row, func(col int, s string) string {
+			if columns[col] == "id" {
+				return cliID(s)
+			}
+			return s
+		})
+	}
+
+	return b.String()
+}
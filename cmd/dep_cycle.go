@@ -19,6 +19,22 @@ var depCycleCmd = &cobra.Command{
 			return err
 		}
 
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			cycles, err := tickets.FindDepCycles(dir)
+			if err != nil {
+				return err
+			}
+			result := cyclesJSON{Cycles: []cycleJSON{}}
+			for _, members := range cycles {
+				c := cycleJSON{Tickets: toTicketRefs(members)}
+				for _, t := range members {
+					c.IDs = append(c.IDs, t.ID)
+				}
+				result.Cycles = append(result.Cycles, c)
+			}
+			return printJSON(result)
+		}
+
 		output, err := tickets.DepCycles(dir)
 		if err != nil {
 			return err
@@ -33,5 +49,6 @@ var depCycleCmd = &cobra.Command{
 }
 
 func init() {
+	addJSONFlag(depCycleCmd, "Output the cycles as a JSON object")
 	depCmd.AddCommand(depCycleCmd)
 }
@@ -11,18 +11,35 @@ import (
 var depTreeCmd = &cobra.Command{
 	Use:   "tree <id>",
 	Short: "Show dependency tree for a ticket",
-	Long:  `Display a tree of dependencies for a ticket using box-drawing characters.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Display a tree of dependencies for a ticket using box-drawing characters.
+
+With --reverse, display the tickets that depend on it instead: everything it
+blocks, transitively.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 		full, _ := cmd.Flags().GetBool("full")
+		reverse, _ := cmd.Flags().GetBool("reverse")
+
+		build, render := tickets.BuildDepTree, tickets.DepTree
+		if reverse {
+			build, render = tickets.BuildReverseDepTree, tickets.ReverseDepTree
+		}
 
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		output, err := tickets.DepTree(dir, id, full)
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			root, err := build(dir, id, full)
+			if err != nil {
+				return err
+			}
+			return printJSON(toTreeNodeJSON(root))
+		}
+
+		output, err := render(dir, id, full)
 		if err != nil {
 			return err
 		}
@@ -35,5 +52,7 @@ var depTreeCmd = &cobra.Command{
 
 func init() {
 	depTreeCmd.Flags().Bool("full", false, "Show full tree without deduplication")
+	depTreeCmd.Flags().Bool("reverse", false, "Show the tickets that depend on this one")
+	addJSONFlag(depTreeCmd, "Output the tree as nested JSON")
 	depCmd.AddCommand(depTreeCmd)
 }
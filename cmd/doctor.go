@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:     "doctor",
+	Aliases: []string{"lint"},
+	Short:   "Report ticket files that can't be parsed",
+	Long: `Report every ticket file that can't be parsed (missing frontmatter delimiter,
+invalid YAML, missing title line), one per line with the parse error.
+
+Unparseable files are left out of list, ready, blocked, closed, query and the TUI.
+With --strict, exits with a non-zero status when any problem is found (for CI).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		_, warnings, err := tickets.ListWithWarnings(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, w := range warnings {
+			fmt.Println(formatParseWarning(dir, w))
+		}
+
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		switch {
+		case len(warnings) == 0:
+			fmt.Println("No problems found")
+		case strict:
+			return fmt.Errorf("%d problem(s) found", len(warnings))
+		default:
+			fmt.Printf("%d problem(s) found\n", len(warnings))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.Flags().Bool("strict", false, "Exit with a non-zero status if any problem is found")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -11,7 +11,10 @@ import (
 var doneCmd = &cobra.Command{
 	Use:   "done <id>",
 	Short: "Mark a ticket as done (remove it)",
-	Long:  `Remove a ticket from the file, marking it as complete.`,
+	Long: `Remove a ticket from the file, marking it as complete.
+
+If the ticket has a recur field, a new ticket is created for the next occurrence
+with the next due date, and the two are linked.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ref := args[0]
@@ -21,17 +24,26 @@ var doneCmd = &cobra.Command{
 			return err
 		}
 
-		title, err := tickets.Done(dir, ref)
+		title, next, err := tickets.Done(dir, ref)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Completed ticket: %s\n", title)
+		printNextOccurrence(next)
 
 		return nil
 	},
 }
 
+// printNextOccurrence prints the ticket created when a recurring ticket is done.
+func printNextOccurrence(next *tickets.Ticket) {
+	if next == nil {
+		return
+	}
+	fmt.Printf("Next occurrence: %s %s (due %s)\n", cliID(next.ID), next.Title, next.Due)
+}
+
 func init() {
 	rootCmd.AddCommand(doneCmd)
 }
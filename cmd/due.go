@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var dueCmd = &cobra.Command{
+	Use:   "due [filter...]",
+	Short: "Show overdue tickets and tickets due this week",
+	Long: `Show the tickets that are not done and are overdue, due today, or due in the
+next 7 days, each group sorted by due date then priority. Tickets can be narrowed
+with a filter expression.
+
+Set due dates with todo add --due or todo set aBc due=friday.
+
+` + filterHelp,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		allItems, err := listTickets(cmd, dir)
+		if err != nil {
+			return err
+		}
+
+		var open []*tickets.Ticket
+		for _, t := range allItems {
+			if !cfg.IsDone(t.Status) && filter.Match(t) {
+				open = append(open, t)
+			}
+		}
+
+		due := tickets.GroupByDue(open, time.Now())
+		first := true
+		for _, group := range []struct {
+			heading string
+			items   []*tickets.Ticket
+		}{
+			{"Overdue", due.Overdue},
+			{"Today", due.Today},
+			{"This week", due.ThisWeek},
+		} {
+			if len(group.items) == 0 {
+				continue
+			}
+			if !first {
+				fmt.Println()
+			}
+			first = false
+
+			fmt.Println(cliHeader.Render(group.heading))
+			for _, t := range group.items {
+				fmt.Printf("  %s (due %s)\n", formatReadyLine(t), t.Due)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dueCmd)
+}
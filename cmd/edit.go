@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
@@ -24,14 +23,12 @@ var editCmd = &cobra.Command{
 			return err
 		}
 
-		// Resolve the ticket (validates existence and handles partial IDs)
-		ticket, err := tickets.Show(dir, ref)
+		// Resolve the ticket file (validates existence and handles partial IDs)
+		ticketPath, err := tickets.FilePath(dir, ref)
 		if err != nil {
 			return err
 		}
 
-		ticketPath := filepath.Join(tickets.DirPath(dir), ticket.ID+".md")
-
 		// If stdout is not a TTY, print the file path
 		if !term.IsTerminal(int(os.Stdout.Fd())) {
 			fmt.Println(ticketPath)
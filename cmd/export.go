@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [filter...]",
+	Short: "Export tickets to CSV, an HTML report or a static site",
+	Long: `Export tickets for people without the CLI. Every ticket is exported, done ones
+included, unless a filter expression narrows them.
+
+  --format csv    one row per ticket with a header, for spreadsheets (default)
+  --format html   a single self-contained HTML page: an index grouped by status
+                  followed by every ticket, with in-page links between them
+  --format site   a directory with index.html, grouped by status, and one
+                  <id>.html page per ticket linking to its parent, children,
+                  deps, blocked tickets and links
+
+CSV and HTML are written to stdout unless --output is given; site needs --output.
+
+  todo export > tickets.csv
+  todo export --format html -o report.html status!=closed
+  todo export --format site -o public --title "Backlog"
+
+` + filterHelp,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		title, _ := cmd.Flags().GetString("title")
+
+		switch format {
+		case "csv", "html":
+		case "site":
+			if output == "" {
+				return fmt.Errorf("--format site requires --output <dir>")
+			}
+		default:
+			return fmt.Errorf("unknown export format %q: must be one of csv, html, site", format)
+		}
+
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		allItems, err := listTickets(cmd, dir)
+		if err != nil {
+			return err
+		}
+		items := tickets.FilterTickets(allItems, filter)
+
+		if format == "site" {
+			if err := tickets.ExportSite(output, title, items, allItems, cfg); err != nil {
+				return err
+			}
+			fmt.Printf("Exported %d tickets to %s\n", len(items), output)
+			return nil
+		}
+
+		export := func(w io.Writer) error {
+			if format == "csv" {
+				return tickets.ExportCSV(w, items)
+			}
+			return tickets.ExportHTML(w, title, items, allItems, cfg)
+		}
+		if output == "" {
+			return export(os.Stdout)
+		}
+
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := export(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", "csv", "Export format: csv, html or site")
+	exportCmd.Flags().StringP("output", "o", "", "Output file (csv, html) or directory (site)")
+	exportCmd.Flags().String("title", "Tickets", "Title of the HTML report or site")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+// filterHelp describes the filter expression syntax for command help texts.
+const filterHelp = `Filter expressions combine conditions; all of them must match unless joined with OR:
+
+  status=open              field comparison (= or :, !=)
+  tag=ui,perf              comma-separated values match any of them
+  priority<=1              numeric comparison (<, <=, >, >=)
+  created>=2026-01-01      date comparison on created, updated, started, closed
+  login "sign up"          words match the title or description
+  title:login              match only the title (or description:)
+  has:parent               the field is set (parent, deps, links, tags, assignee, ...)
+  dep:aBc, link:aBc        depends on or links to a ticket
+  NOT a, -a                negation
+  a OR b, (a OR b) c       alternatives and grouping`
+
+// filterFromFlags builds a ticket filter from the filter expression in args and
+// the --status, --type, --assignee and --tag flags, for the ones the command defines.
+// All of them must match.
+func filterFromFlags(cmd *cobra.Command, args []string) (*tickets.Filter, error) {
+	expr, err := tickets.ParseFilter(strings.Join(args, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []*tickets.Filter{expr}
+	flags := cmd.Flags()
+	for _, field := range []string{"status", "type", "assignee", "tag"} {
+		if flags.Lookup(field) == nil {
+			continue
+		}
+		value, _ := flags.GetString(field)
+		f, err := tickets.FieldFilter(field, value)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	return tickets.AllOf(filters...), nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/juanibiapina/todo/internal/tickets"
@@ -10,13 +11,29 @@ import (
 
 // CLI colors matching TUI style (ANSI 0-15)
 var (
-	cliMagenta = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	cliMagenta   = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	cliHighlight = lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+	cliHeader    = lipgloss.NewStyle().Bold(true)
+	cliOverdue   = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
 )
 
 func cliID(id string) string {
 	return cliMagenta.Render(id)
 }
 
+// highlightSpans renders the given byte ranges of s in the highlight style.
+func highlightSpans(s string, spans []tickets.Span) string {
+	var b strings.Builder
+	pos := 0
+	for _, span := range spans {
+		b.WriteString(s[pos:span.Start])
+		b.WriteString(cliHighlight.Render(s[span.Start:span.End]))
+		pos = span.End
+	}
+	b.WriteString(s[pos:])
+	return b.String()
+}
+
 func formatReadyLine(t *tickets.Ticket) string {
 	var b strings.Builder
 
@@ -29,6 +46,10 @@ func formatReadyLine(t *tickets.Ticket) string {
 	}
 	b.WriteString(fmt.Sprintf("[%s]", status))
 
+	if t.IsOverdue(time.Now()) {
+		b.WriteString(cliOverdue.Render("[overdue]"))
+	}
+
 	b.WriteString(" - ")
 	b.WriteString(t.Title)
 
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [id]",
+	Short: "Render the dependency graph as Graphviz or Mermaid",
+	Long: `Render the dependency graph of all tickets that are not done, or only the tickets
+reachable from an ID through deps and children.
+
+Nodes are filled by status (open white, in_progress yellow, done gray, other
+statuses blue) and P0/P1 tickets get a red/orange border. Deps are solid arrows
+from a ticket to what it depends on, parent/child edges are dashed arrows, links
+are dotted lines, and deps that form a cycle are drawn in red.
+
+  todo graph | dot -Tsvg > deps.svg
+  todo graph --format mermaid aBc     # paste into a markdown ` + "```mermaid" + ` block`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		if format != "dot" && format != "mermaid" {
+			return fmt.Errorf("unknown graph format %q: must be one of dot, mermaid", format)
+		}
+
+		opts := tickets.GraphOptions{}
+		opts.IncludeDone, _ = cmd.Flags().GetBool("closed")
+		if len(args) == 1 {
+			opts.Root = args[0]
+		}
+
+		graph, err := tickets.BuildGraph(dir, opts)
+		if err != nil {
+			return err
+		}
+
+		if format == "mermaid" {
+			fmt.Println(graph.Mermaid())
+		} else {
+			fmt.Println(graph.DOT())
+		}
+		return nil
+	},
+}
+
+func init() {
+	graphCmd.Flags().String("format", "dot", "Output format: dot or mermaid")
+	graphCmd.Flags().Bool("closed", false, "Include done tickets")
+	rootCmd.AddCommand(graphCmd)
+}
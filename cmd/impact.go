@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var impactCmd = &cobra.Command{
+	Use:   "impact [id]",
+	Short: "Show what closing a ticket would unblock",
+	Long: `Show how many open tickets would become ready if a ticket were closed (their
+only unfinished dep is this ticket), and how many depend on it transitively.
+Each count comes with a priority weight: the lowest priority weighs 1 and each
+level above adds 1 (P0 weighs 5 with the default 0-4 range).
+
+Without an ID, rank every ticket that blocks others by the weight it would
+unblock, to pick which blocker to attack first.
+
+  todo impact aBc
+  todo impact | head -5`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			ranked, err := tickets.RankImpact(dir)
+			if err != nil {
+				return err
+			}
+			for _, imp := range ranked {
+				fmt.Println(formatImpactLine(imp))
+			}
+			return nil
+		}
+
+		imp, err := tickets.ComputeImpact(dir, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Closing %s %s unblocks %s (weight %d)\n",
+			cliID(imp.Ticket.ID), imp.Ticket.Title, pluralTickets(len(imp.Unblocked)), imp.UnblockedWeight)
+		for _, t := range imp.Unblocked {
+			fmt.Println("  " + formatReadyLine(t))
+		}
+		fmt.Printf("Transitively blocked: %s (weight %d)\n", pluralTickets(len(imp.Blocked)), imp.BlockedWeight)
+		for _, t := range imp.Blocked {
+			fmt.Println("  " + formatReadyLine(t))
+		}
+		return nil
+	},
+}
+
+// formatImpactLine renders a ranked ticket as
+// "id [P<priority>][status] - Title (unblocks N, weight W; blocks M, weight V)".
+func formatImpactLine(imp *tickets.Impact) string {
+	return fmt.Sprintf("%s (unblocks %d, weight %d; blocks %d, weight %d)",
+		formatReadyLine(imp.Ticket), len(imp.Unblocked), imp.UnblockedWeight, len(imp.Blocked), imp.BlockedWeight)
+}
+
+// pluralTickets returns "1 ticket" or "N tickets".
+func pluralTickets(n int) string {
+	if n == 1 {
+		return "1 ticket"
+	}
+	return fmt.Sprintf("%d tickets", n)
+}
+
+func init() {
+	rootCmd.AddCommand(impactCmd)
+}
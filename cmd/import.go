@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Create or update tickets from JSONL, GitHub issues or Jira exports",
+	Long: `Create or update tickets from JSON Lines in the format of todo query, read from
+a file or stdin.
+
+A record whose id matches an existing ticket updates the fields present in the
+record, and each change is recorded in the ticket's history. Other records create
+tickets with their id, or a generated one when the id is missing, and the default
+type and priority when those are missing. With --new-ids every record creates a
+ticket with a fresh ID, and deps, links, parents and history entries pointing at
+other imported records are rewritten. IDs mentioned in free text, like the
+description, are left as they are.
+
+Records are validated like todo add (title, type, status, priority), status
+changes must follow the configured transitions, and parents, deps and links must
+exist; if any record is invalid, nothing is written.
+
+  todo query --status open | jq -c '.priority = 1' | todo import --dry-run
+  todo query > tickets.jsonl; cd ../other-repo; todo import --new-ids tickets.jsonl
+
+--from github reads the JSON array printed by gh issue list, and --from jira
+reads a Jira CSV or XML export. Labels become tags, the first assignee becomes
+the assignee, the state or status maps to open, in_progress or the done status,
+and the issue type (a label naming a type, for GitHub) sets the type. The issue
+key (owner/repo#123 or PROJ-123) is kept in external_ref: running the import
+again updates the tickets with a matching external_ref instead of creating new
+ones, leaving fields the source doesn't have (deps, parent, design) alone. The
+local status is kept unless the issue was closed or reopened, and labels are
+added to the existing tags.
+
+  gh issue list --state all --json number,title,body,state,labels,assignees,url,createdAt,closedAt \
+    | todo import --from github
+  todo import --from jira export.csv`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		in := io.Reader(os.Stdin)
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		newIDs, _ := cmd.Flags().GetBool("new-ids")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		from, _ := cmd.Flags().GetString("from")
+		opts := tickets.ImportOptions{NewIDs: newIDs, DryRun: dryRun}
+
+		var records []tickets.ImportRecord
+		switch from {
+		case "jsonl":
+			records, err = readJSONLRecords(in)
+		case "github", "jira":
+			if newIDs {
+				return fmt.Errorf("--new-ids can't be used with --from %s", from)
+			}
+			records, err = readExternalRecords(in, from, dir)
+			opts.ByExternalRef = true
+		default:
+			return fmt.Errorf("unknown import source %q: must be one of jsonl, github, jira", from)
+		}
+		if err != nil {
+			return err
+		}
+
+		actions, err := tickets.Import(dir, records, opts)
+		if err != nil {
+			return err
+		}
+
+		printImportActions(actions, dryRun)
+		return nil
+	},
+}
+
+// readJSONLRecords decodes one queryTicket per non-empty line, keeping the keys
+// each line has so updates only touch those fields. Unknown fields are rejected so
+// typos don't get silently dropped.
+func readJSONLRecords(r io.Reader) ([]tickets.ImportRecord, error) {
+	var records []tickets.ImportRecord
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var q queryTicket
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&q); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(text, &keys); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		fields := make([]string, 0, len(keys))
+		for k := range keys {
+			fields = append(fields, k)
+		}
+		records = append(records, tickets.ImportRecord{Ticket: fromQueryTicket(q), Fields: fields})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	return records, nil
+}
+
+// readExternalRecords reads a GitHub issues or Jira export. The records carry the
+// fields the source sets.
+func readExternalRecords(r io.Reader, from string, dir string) ([]tickets.ImportRecord, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading input: %w", err)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if from == "github" {
+		return tickets.ParseGitHubIssues(data, cfg)
+	}
+	return tickets.ParseJira(data, cfg)
+}
+
+// printImportActions prints one line per created, updated or skipped ticket, the
+// changed fields of updates, and a summary.
+func printImportActions(actions []tickets.ImportAction, dryRun bool) {
+	created, updated, unchanged := "Created", "Updated", 0
+	if dryRun {
+		created, updated = "Would create", "Would update"
+	}
+
+	var nCreated, nUpdated, nSkipped int
+	for _, a := range actions {
+		switch {
+		case a.Archived:
+			nSkipped++
+			fmt.Printf("Skipped %s %s (archived)\n", cliID(a.Ticket.ID), a.Ticket.Title)
+		case a.Created:
+			nCreated++
+			line := fmt.Sprintf("%s %s %s", created, cliID(a.Ticket.ID), a.Ticket.Title)
+			if a.SourceID != "" {
+				line += fmt.Sprintf(" (was %s)", a.SourceID)
+			}
+			fmt.Println(line)
+		case len(a.Changes) > 0:
+			nUpdated++
+			fmt.Printf("%s %s %s\n", updated, cliID(a.Ticket.ID), a.Ticket.Title)
+			for _, c := range a.Changes {
+				fmt.Println("  " + formatFieldDiff(c))
+			}
+		default:
+			unchanged++
+		}
+	}
+
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged", nCreated, nUpdated, unchanged)
+	if nSkipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", nSkipped)
+	}
+	fmt.Println(summary)
+}
+
+// formatFieldDiff renders a change as "field: old -> new". Free-text fields,
+// which don't carry values, render as "field: changed".
+func formatFieldDiff(c tickets.FieldDiff) string {
+	if c.Old == "" && c.New == "" {
+		return c.Field + ": changed"
+	}
+	quote := func(s string) string {
+		if s == "" || strings.ContainsAny(s, " ,") {
+			return fmt.Sprintf("%q", s)
+		}
+		return s
+	}
+	return fmt.Sprintf("%s: %s -> %s", c.Field, quote(c.Old), quote(c.New))
+}
+
+func init() {
+	importCmd.Flags().Bool("new-ids", false, "Give every imported ticket a new ID and rewrite references between them")
+	importCmd.Flags().String("from", "jsonl", "Input format: jsonl, github or jira")
+	importCmd.Flags().Bool("dry-run", false, "Show what would change without writing anything")
+	rootCmd.AddCommand(importCmd)
+}
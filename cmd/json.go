@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+// ticketRef is a short JSON reference to a related ticket.
+type ticketRef struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status,omitempty"`
+}
+
+func toTicketRef(t *tickets.Ticket) ticketRef {
+	return ticketRef{ID: t.ID, Title: t.Title, Status: t.Status}
+}
+
+func toTicketRefs(items []*tickets.Ticket) []ticketRef {
+	refs := []ticketRef{}
+	for _, t := range items {
+		refs = append(refs, toTicketRef(t))
+	}
+	return refs
+}
+
+// showTicket is the JSON form of `todo show`: every field plus the computed relations.
+type showTicket struct {
+	queryTicket
+	ParentTicket *ticketRef  `json:"parent_ticket,omitempty"`
+	Blockers     []ticketRef `json:"blockers"`
+	Blocking     []ticketRef `json:"blocking"`
+	Children     []ticketRef `json:"children"`
+	Linked       []ticketRef `json:"linked"`
+}
+
+func toShowTicket(t *tickets.Ticket, rel *tickets.TicketRelations) showTicket {
+	s := showTicket{
+		queryTicket: toQueryTicket(t),
+		Blockers:    toTicketRefs(rel.Blockers),
+		Blocking:    toTicketRefs(rel.Blocking),
+		Children:    toTicketRefs(rel.Children),
+		Linked:      toTicketRefs(rel.Linked),
+	}
+	if rel.ParentTicket != nil {
+		ref := toTicketRef(rel.ParentTicket)
+		s.ParentTicket = &ref
+	}
+	return s
+}
+
+// blockedTicketJSON is the JSON form of a `todo blocked` line: every field plus the
+// IDs of the deps that are not done.
+type blockedTicketJSON struct {
+	queryTicket
+	Blockers []string `json:"blockers"`
+}
+
+// treeNodeJSON is the JSON form of a dependency tree node. Marked nodes ("cycle"
+// or "dup") are not expanded, like in the text tree.
+type treeNodeJSON struct {
+	ticketRef
+	Marker string         `json:"marker,omitempty"`
+	Deps   []treeNodeJSON `json:"deps"`
+}
+
+func toTreeNodeJSON(n *tickets.TreeNode) treeNodeJSON {
+	node := treeNodeJSON{
+		ticketRef: toTicketRef(n.Ticket),
+		Marker:    strings.Trim(n.Marker, "()"),
+		Deps:      []treeNodeJSON{},
+	}
+	for _, child := range n.Children {
+		node.Deps = append(node.Deps, toTreeNodeJSON(child))
+	}
+	return node
+}
+
+// cycleJSON is the JSON form of a dependency cycle.
+type cycleJSON struct {
+	IDs     []string    `json:"ids"`
+	Tickets []ticketRef `json:"tickets"`
+}
+
+// cyclesJSON is the JSON form of `todo dep cycle`: an object, like the output of
+// the other --json commands, rather than a bare array.
+type cyclesJSON struct {
+	Cycles []cycleJSON `json:"cycles"`
+}
+
+// addJSONFlag registers the --json flag on a command.
+func addJSONFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().Bool("json", false, usage)
+}
+
+// printJSON prints a value as a single line of JSON.
+func printJSON(v any) error {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Println(string(jsonBytes))
+	return nil
+}
+
+// printJSONLines prints one JSON object per ticket, like `todo query`.
+func printJSONLines(items []*tickets.Ticket) error {
+	for _, t := range items {
+		if err := printJSON(toQueryTicket(t)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
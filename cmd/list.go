@@ -4,71 +4,98 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [filter...]",
 	Short: "List all tickets",
-	Long:  `List all tickets with their ID and title.`,
-	Args:  cobra.NoArgs,
+	Long: `List all tickets with their ID and title. Tickets can be narrowed with a filter expression.
+
+` + filterHelp + "\n\n" + formatHelp,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		allItems, err := tickets.List(dir)
+		cfg, err := config.Load(dir)
 		if err != nil {
 			return err
 		}
 
-		statusFilter, _ := cmd.Flags().GetString("status")
-		assigneeFilter, _ := cmd.Flags().GetString("assignee")
-		tagFilter, _ := cmd.Flags().GetString("tag")
+		allItems, err := listTickets(cmd, dir)
+		if err != nil {
+			return err
+		}
 
-		var items []*tickets.Ticket
-		for _, t := range allItems {
-			// Default behavior: hide closed tickets unless --status is specified
-			if statusFilter == "" && t.Status == "closed" {
-				continue
-			}
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		sortSpec, _ := cmd.Flags().GetString("sort")
+		sortKeys, err := tickets.ParseSortKeys(sortSpec)
+		if err != nil {
+			return err
+		}
 
-			// Apply --status filter
-			// "open" matches both explicit "open" and empty status (default)
-			if statusFilter != "" {
-				if statusFilter == "open" {
-					if t.Status != "" && t.Status != "open" {
-						continue
-					}
-				} else if t.Status != statusFilter {
-					continue
-				}
+		var columns []string
+		if cmd.Flags().Changed("columns") {
+			spec, _ := cmd.Flags().GetString("columns")
+			columns, err = parseColumns(spec)
+			if err != nil {
+				return err
 			}
+		} else if term.IsTerminal(int(os.Stdout.Fd())) {
+			columns = defaultColumns
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
 
-			// Apply --assignee filter
-			if assigneeFilter != "" && t.Assignee != assigneeFilter {
+		tmpl, err := templateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		var items []*tickets.Ticket
+		for _, t := range allItems {
+			// Default behavior: hide done tickets unless the filter selects a status
+			if !filter.HasField("status") && cfg.IsDone(t.Status) {
 				continue
 			}
 
-			// Apply --tag filter
-			if tagFilter != "" {
-				found := false
-				for _, tag := range t.Tags {
-					if tag == tagFilter {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
+			if !filter.Match(t) {
+				continue
 			}
 
 			items = append(items, t)
 		}
 
+		tickets.SortTickets(items, sortKeys)
+		if limit > 0 && len(items) > limit {
+			items = items[:limit]
+		}
+
+		if tmpl != nil {
+			return printTemplate(tmpl, items, allItems, cfg)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSONLines(items)
+		}
+
+		if columns != nil {
+			if len(items) > 0 {
+				fmt.Print(formatTicketTable(items, columns))
+			}
+			return nil
+		}
+
 		for _, t := range items {
 			fmt.Println(formatTicketLine(t))
 		}
@@ -81,5 +108,13 @@ func init() {
 	listCmd.Flags().String("status", "", "Filter by status (open, in_progress, closed)")
 	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	listCmd.Flags().StringP("tag", "T", "", "Filter by tag")
+	listCmd.Flags().String("sort", "", "Sort by comma-separated fields, '-' for descending (e.g. priority,-created,title)")
+	listCmd.Flags().String("columns", "", "Comma-separated columns to show (id, priority, status, type, assignee, tags, parent, deps, created, updated, title); defaults to id,priority,status,type,assignee,tags,title on a terminal")
+	listCmd.Flags().IntP("limit", "n", 0, "Maximum number of tickets to show (0 for no limit)")
+	addFormatFlag(listCmd)
+	addJSONFlag(listCmd, "Output tickets as JSON Lines, like todo query")
+	listCmd.MarkFlagsMutuallyExclusive("format", "json")
+	listCmd.MarkFlagsMutuallyExclusive("format", "columns")
+	listCmd.MarkFlagsMutuallyExclusive("json", "columns")
 	rootCmd.AddCommand(listCmd)
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <id>",
+	Short: "Show a ticket's change history",
+	Long:  `Show the recorded history of changes to a ticket (status, deps, links, notes, description), oldest first. Each entry shows the time, the git user.name of the actor, the field and the change.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := args[0]
+
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		ticket, err := tickets.Show(dir, ref)
+		if err != nil {
+			return err
+		}
+
+		output := tickets.FormatHistory(ticket)
+		if output != "" {
+			fmt.Println(output)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+}
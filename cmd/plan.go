@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Schedule open tickets into waves and find the critical path",
+	Long: `Sort all open tickets by their deps and group them into waves that can be
+worked on in parallel: wave 1 is what todo ready shows, wave 2 only depends on
+wave 1, and so on. Deps on done or missing tickets don't block.
+
+The critical path is the chain of deps with the most estimated work (the
+estimate field, e.g. 4h, 1.5d, 2w, with 8h days and 5-day weeks); it bounds how
+soon everything can be done. Without estimates it is the longest chain.
+
+Planning fails if the deps form cycles; todo dep cycle shows them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		plan, err := tickets.BuildPlan(dir)
+		if err != nil {
+			return err
+		}
+
+		for i, wave := range plan.Waves {
+			var work time.Duration
+			for _, t := range wave {
+				work += t.EstimateDuration()
+			}
+			fmt.Println(cliHeader.Render(fmt.Sprintf("Wave %d (%s, %s)", i+1, pluralTickets(len(wave)), tickets.FormatEstimate(work))))
+			for _, t := range wave {
+				fmt.Println("  " + formatPlanLine(t))
+			}
+			fmt.Println()
+		}
+
+		if len(plan.CriticalPath) > 0 {
+			var ids []string
+			for _, t := range plan.CriticalPath {
+				ids = append(ids, cliID(t.ID))
+			}
+			fmt.Printf("Critical path (%s, %s): %s\n", pluralTickets(len(plan.CriticalPath)),
+				tickets.FormatEstimate(plan.CriticalEstimate), strings.Join(ids, " -> "))
+		}
+
+		total := fmt.Sprintf("Total: %s, %s", pluralTickets(len(plan.Order())), tickets.FormatEstimate(plan.TotalEstimate))
+		if n := len(plan.Unestimated); n > 0 {
+			total += fmt.Sprintf(" (%d without estimate)", n)
+		}
+		fmt.Println(total)
+		return nil
+	},
+}
+
+// formatPlanLine renders a ticket like formatReadyLine, followed by its estimate.
+func formatPlanLine(t *tickets.Ticket) string {
+	line := formatReadyLine(t)
+	if t.Estimate != "" {
+		line += fmt.Sprintf(" (%s)", t.Estimate)
+	}
+	return line
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+}
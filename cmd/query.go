@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
 	"os"
 
 	"github.com/juanibiapina/todo/internal/tickets"
@@ -19,14 +17,25 @@ type queryTicket struct {
 	Priority    int      `json:"priority"`
 	Assignee    string   `json:"assignee,omitempty"`
 	Created     string   `json:"created,omitempty"`
+	Updated     string   `json:"updated,omitempty"`
+	StartedAt   string   `json:"started_at,omitempty"`
+	ClosedAt    string   `json:"closed_at,omitempty"`
 	Parent      string   `json:"parent,omitempty"`
 	ExternalRef string   `json:"external_ref,omitempty"`
 	Design      string   `json:"design,omitempty"`
 	Acceptance  string   `json:"acceptance,omitempty"`
+	Estimate    string   `json:"estimate,omitempty"`
+	Spent       string   `json:"spent,omitempty"`
+	Due         string   `json:"due,omitempty"`
+	Scheduled   string   `json:"scheduled,omitempty"`
+	Recur       string   `json:"recur,omitempty"`
 	Description string   `json:"description,omitempty"`
 	Deps        []string `json:"deps"`
 	Links       []string `json:"links"`
 	Tags        []string `json:"tags"`
+
+	History  []tickets.HistoryEntry `json:"history,omitempty"`
+	Sessions []tickets.WorkSession  `json:"sessions,omitempty"`
 }
 
 func toQueryTicket(t *tickets.Ticket) queryTicket {
@@ -38,14 +47,24 @@ func toQueryTicket(t *tickets.Ticket) queryTicket {
 		Priority:    t.Priority,
 		Assignee:    t.Assignee,
 		Created:     t.Created,
+		Updated:     t.Updated,
+		StartedAt:   t.StartedAt,
+		ClosedAt:    t.ClosedAt,
 		Parent:      t.Parent,
 		ExternalRef: t.ExternalRef,
 		Design:      t.Design,
 		Acceptance:  t.Acceptance,
+		Estimate:    t.Estimate,
+		Spent:       t.Spent,
+		Due:         t.Due,
+		Scheduled:   t.Scheduled,
+		Recur:       t.Recur,
 		Description: t.Description,
 		Deps:        t.Deps,
 		Links:       t.Links,
 		Tags:        t.Tags,
+		History:     t.History,
+		Sessions:    t.Sessions,
 	}
 
 	// Ensure slices are never null in JSON output
@@ -62,72 +81,69 @@ func toQueryTicket(t *tickets.Ticket) queryTicket {
 	return q
 }
 
+// fromQueryTicket converts a JSON record back into a ticket, for `todo import`.
+func fromQueryTicket(q queryTicket) *tickets.Ticket {
+	return &tickets.Ticket{
+		ID:          q.ID,
+		Title:       q.Title,
+		Status:      q.Status,
+		Type:        q.Type,
+		Priority:    q.Priority,
+		Assignee:    q.Assignee,
+		Created:     q.Created,
+		Updated:     q.Updated,
+		StartedAt:   q.StartedAt,
+		ClosedAt:    q.ClosedAt,
+		Parent:      q.Parent,
+		ExternalRef: q.ExternalRef,
+		Design:      q.Design,
+		Acceptance:  q.Acceptance,
+		Estimate:    q.Estimate,
+		Spent:       q.Spent,
+		Due:         q.Due,
+		Scheduled:   q.Scheduled,
+		Recur:       q.Recur,
+		Description: q.Description,
+		Deps:        q.Deps,
+		Links:       q.Links,
+		Tags:        q.Tags,
+		History:     q.History,
+		Sessions:    q.Sessions,
+	}
+}
+
 var queryCmd = &cobra.Command{
-	Use:   "query",
+	Use:   "query [filter...]",
 	Short: "Output tickets as JSONL",
-	Long:  `Output all tickets as JSON Lines (one JSON object per line) with all frontmatter fields. Supports filtering by status, type, assignee, and tag.`,
-	Args:  cobra.NoArgs,
+	Long: `Output all tickets as JSON Lines (one JSON object per line) with all frontmatter fields. Supports filtering by status, type, assignee, and tag. Archived tickets are included with --archived. Tickets can be narrowed with a filter expression.
+
+` + filterHelp,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		allItems, err := tickets.List(dir)
+		allItems, err := listTickets(cmd, dir)
 		if err != nil {
 			return err
 		}
 
-		statusFilter, _ := cmd.Flags().GetString("status")
-		typeFilter, _ := cmd.Flags().GetString("type")
-		assigneeFilter, _ := cmd.Flags().GetString("assignee")
-		tagFilter, _ := cmd.Flags().GetString("tag")
-
-		for _, t := range allItems {
-			// Apply --status filter
-			if statusFilter != "" {
-				if statusFilter == "open" {
-					if t.Status != "" && t.Status != "open" {
-						continue
-					}
-				} else if t.Status != statusFilter {
-					continue
-				}
-			}
-
-			// Apply --type filter
-			if typeFilter != "" && t.Type != typeFilter {
-				continue
-			}
-
-			// Apply --assignee filter
-			if assigneeFilter != "" && t.Assignee != assigneeFilter {
-				continue
-			}
-
-			// Apply --tag filter
-			if tagFilter != "" {
-				found := false
-				for _, tag := range t.Tags {
-					if tag == tagFilter {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
-			}
-
-			q := toQueryTicket(t)
-			jsonBytes, err := json.Marshal(q)
+		if archived, _ := cmd.Flags().GetBool("archived"); archived {
+			archivedItems, err := tickets.ListArchived(dir)
 			if err != nil {
-				return fmt.Errorf("failed to marshal ticket %s: %w", t.ID, err)
+				return err
 			}
-			fmt.Println(string(jsonBytes))
+			allItems = append(allItems, archivedItems...)
+		}
+
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		return printJSONLines(tickets.FilterTickets(allItems, filter))
 	},
 }
 
@@ -136,5 +152,6 @@ func init() {
 	queryCmd.Flags().String("type", "", "Filter by type (bug, feature, task, epic, chore)")
 	queryCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	queryCmd.Flags().StringP("tag", "T", "", "Filter by tag")
+	queryCmd.Flags().Bool("archived", false, "Include archived tickets")
 	rootCmd.AddCommand(queryCmd)
 }
@@ -3,24 +3,34 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"sort"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
 )
 
 var readyCmd = &cobra.Command{
-	Use:   "ready",
+	Use:   "ready [filter...]",
 	Short: "Show tickets ready to work on",
-	Long:  `Show open/in_progress tickets with all deps closed or no deps, sorted by priority then ID.`,
-	Args:  cobra.NoArgs,
+	Long: `Show tickets that are not done with all deps done or no deps, sorted by priority then ID. Tickets can be narrowed with a filter expression.
+
+Tickets past their due date are marked [overdue]. With --by-due, tickets are sorted
+by due date first (tickets without one last), then by priority.
+
+` + filterHelp + "\n\n" + formatHelp,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		allItems, err := tickets.List(dir)
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		allItems, err := listTickets(cmd, dir)
 		if err != nil {
 			return err
 		}
@@ -31,21 +41,28 @@ var readyCmd = &cobra.Command{
 			statusMap[t.ID] = t.Status
 		}
 
-		assigneeFilter, _ := cmd.Flags().GetString("assignee")
-		tagFilter, _ := cmd.Flags().GetString("tag")
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := templateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
 
 		var ready []*tickets.Ticket
 		for _, t := range allItems {
-			// Only open/in_progress tickets (not closed)
-			if t.Status == "closed" {
+			// Only tickets that are not done
+			if cfg.IsDone(t.Status) {
 				continue
 			}
 
-			// Check all deps are closed or missing (non-blocking)
+			// Check all deps are done or missing (non-blocking)
 			allDepsDone := true
 			for _, depID := range t.Deps {
 				depStatus, exists := statusMap[depID]
-				if exists && depStatus != "closed" {
+				if exists && !cfg.IsDone(depStatus) {
 					allDepsDone = false
 					break
 				}
@@ -55,35 +72,27 @@ var readyCmd = &cobra.Command{
 				continue
 			}
 
-			// Apply --assignee filter
-			if assigneeFilter != "" && t.Assignee != assigneeFilter {
+			if !filter.Match(t) {
 				continue
 			}
 
-			// Apply --tag filter
-			if tagFilter != "" {
-				found := false
-				for _, tag := range t.Tags {
-					if tag == tagFilter {
-						found = true
-						break
-					}
-				}
-				if !found {
-					continue
-				}
-			}
-
 			ready = append(ready, t)
 		}
 
 		// Sort by priority ascending, then by ID ascending
-		sort.Slice(ready, func(i, j int) bool {
-			if ready[i].Priority != ready[j].Priority {
-				return ready[i].Priority < ready[j].Priority
-			}
-			return ready[i].ID < ready[j].ID
-		})
+		keys := []tickets.SortKey{{Field: "priority"}}
+		if byDue, _ := cmd.Flags().GetBool("by-due"); byDue {
+			keys = append([]tickets.SortKey{{Field: "due"}}, keys...)
+		}
+		tickets.SortTickets(ready, keys)
+
+		if tmpl != nil {
+			return printTemplate(tmpl, ready, allItems, cfg)
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSONLines(ready)
+		}
 
 		for _, t := range ready {
 			fmt.Println(formatReadyLine(t))
@@ -96,5 +105,9 @@ var readyCmd = &cobra.Command{
 func init() {
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	readyCmd.Flags().StringP("tag", "T", "", "Filter by tag")
+	readyCmd.Flags().Bool("by-due", false, "Sort by due date before priority")
+	addFormatFlag(readyCmd)
+	addJSONFlag(readyCmd, "Output tickets as JSON Lines, like todo query")
+	readyCmd.MarkFlagsMutuallyExclusive("format", "json")
 	rootCmd.AddCommand(readyCmd)
 }
@@ -21,7 +21,7 @@ var reopenCmd = &cobra.Command{
 			return err
 		}
 
-		title, err := tickets.SetStatus(dir, id, "open")
+		title, _, err := tickets.SetStatus(dir, id, "open")
 		if err != nil {
 			return err
 		}
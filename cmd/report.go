@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report on tickets",
+}
+
+var reportTimeCmd = &cobra.Command{
+	Use:   "time [filter...]",
+	Short: "Sum tracked time by assignee, tag or parent",
+	Long: `Sum the work sessions recorded with todo track, grouped by assignee, tag or
+parent. --since and --until take a date (YYYY-MM-DD, whole UTC days) or an
+RFC3339 time; sessions crossing them are clipped, and a running session counts
+until now. Without them, spent time set by hand (todo set <id> spent=2h) counts
+too; it has no date, so a range leaves it out. Done tickets are included. A
+ticket with several tags counts in each of them, so the tag rows can add up to
+more than the total.
+
+  todo report time --since 2026-10-01 --by assignee
+  todo report time --since 2026-10-01 --until 2026-10-31 --by parent tag=billing
+
+` + filterHelp,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		var since, until time.Time
+		if value, _ := cmd.Flags().GetString("since"); value != "" {
+			if since, _, err = tickets.ParseDateRange(value); err != nil {
+				return err
+			}
+		}
+		if value, _ := cmd.Flags().GetString("until"); value != "" {
+			if _, until, err = tickets.ParseDateRange(value); err != nil {
+				return err
+			}
+		}
+		by, _ := cmd.Flags().GetString("by")
+
+		filter, err := filterFromFlags(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		allItems, err := listTickets(cmd, dir)
+		if err != nil {
+			return err
+		}
+
+		rows, total, err := tickets.TimeReport(tickets.FilterTickets(allItems, filter), allItems, by, since, until)
+		if err != nil {
+			return err
+		}
+
+		none := map[string]string{"assignee": "(unassigned)", "tag": "(untagged)", "parent": "(no parent)"}[by]
+		labels := make([]string, len(rows))
+		width := len("Total")
+		for i, row := range rows {
+			labels[i] = row.Label
+			if labels[i] == "" {
+				labels[i] = none
+			}
+			width = max(width, len(labels[i]))
+		}
+
+		for i, row := range rows {
+			fmt.Printf("%-*s  %-12s %s\n", width, labels[i], tickets.FormatEstimate(row.Spent), pluralTickets(row.Tickets))
+		}
+		fmt.Println(cliHeader.Render(fmt.Sprintf("%-*s  %s", width, "Total", tickets.FormatEstimate(total))))
+		return nil
+	},
+}
+
+func init() {
+	reportTimeCmd.Flags().String("since", "", "Only count time from this date (YYYY-MM-DD or RFC3339)")
+	reportTimeCmd.Flags().String("until", "", "Only count time up to the end of this date")
+	reportTimeCmd.Flags().String("by", "assignee", "Group by assignee, tag or parent")
+	reportCmd.AddCommand(reportTimeCmd)
+	rootCmd.AddCommand(reportCmd)
+}
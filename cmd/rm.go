@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var rmCmd = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "Delete a ticket and remove references to it",
+	Long: `Delete a ticket file and remove its ID from the deps and links of every other
+ticket, archived ones included, so no dangling references are left behind.
+
+Children of the deleted ticket are left without a parent, or moved to the deleted
+ticket's own parent with --reparent.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := args[0]
+
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		reparent, _ := cmd.Flags().GetBool("reparent")
+
+		removed, updated, err := tickets.Remove(dir, ref, reparent)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Removed %s %s\n", cliID(removed.ID), removed.Title)
+		for _, id := range updated {
+			fmt.Printf("Updated %s\n", cliID(id))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rmCmd.Flags().Bool("reparent", false, "Move children to the deleted ticket's parent instead of orphaning them")
+	rootCmd.AddCommand(rmCmd)
+}
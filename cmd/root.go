@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -16,7 +17,19 @@ Tickets are stored in a docs/tickets/ directory, one file per ticket. Each ticke
 has a title, a 3-character ID, and an optional description.
 
 Descriptions can be passed via stdin to support multi-line content with backticks,
-code blocks, and any special characters without shell escaping issues.`,
+code blocks, and any special characters without shell escaping issues.
+
+Allowed types, statuses, priorities, defaults and the tickets directory can be
+configured in a .todo.yaml file in the current directory.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Fail early on an invalid config file
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		_, err = config.Load(dir)
+		return err
+	},
 }
 
 func Execute() {
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <terms...>",
+	Short: "Search ticket text",
+	Long: `Search titles, descriptions, design, acceptance criteria and notes of all tickets.
+
+Every term must appear in the ticket (case-insensitive). Results are ranked by
+relevance, with title matches above body matches, and show a snippet around the
+best body match. Done tickets are only searched with --closed.
+
+  todo search login timeout
+  todo search --regex 'time ?out'
+  todo search --closed flaky`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		allItems, err := listTickets(cmd, dir)
+		if err != nil {
+			return err
+		}
+
+		regex, _ := cmd.Flags().GetBool("regex")
+		includeClosed, _ := cmd.Flags().GetBool("closed")
+
+		var items []*tickets.Ticket
+		for _, t := range allItems {
+			if !includeClosed && cfg.IsDone(t.Status) {
+				continue
+			}
+			items = append(items, t)
+		}
+
+		results, err := tickets.Search(items, args, regex)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			fmt.Println(formatSearchResult(r))
+		}
+
+		return nil
+	},
+}
+
+// formatSearchResult renders a result as the ready line with highlighted title,
+// followed by an indented "field: snippet" line when the body matched.
+func formatSearchResult(r tickets.SearchResult) string {
+	t := r.Ticket
+
+	status := t.Status
+	if status == "" {
+		status = "open"
+	}
+	line := fmt.Sprintf("%s [P%d][%s] - %s", cliID(t.ID), t.Priority, status, highlightSpans(r.Title, r.TitleHighlights))
+
+	if r.Snippet != "" {
+		line += fmt.Sprintf("\n    %s: %s", r.SnippetField, highlightSpans(r.Snippet, r.SnippetHighlights))
+	}
+
+	return line
+}
+
+func init() {
+	searchCmd.Flags().BoolP("regex", "e", false, "Treat terms as regular expressions")
+	searchCmd.Flags().Bool("closed", false, "Include done tickets")
+	rootCmd.AddCommand(searchCmd)
+}
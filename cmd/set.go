@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set <id>... <field=value>...",
+	Short: "Change ticket fields",
+	Long: `Change one or more fields on one or more tickets.
+
+Fields: title, status, type, priority, assignee, parent, external_ref, design,
+acceptance, estimate, spent, due, scheduled, recur, tags. Use field= to clear a field. Tags can be
+replaced (tags=a,b), added (tags+=perf) or removed (tags-=ui). Dates take YYYY-MM-DD, today,
+tomorrow, a weekday (friday) or an offset (+3d, +2w, +1m).
+
+Values are validated like in add: type, status and priority must be allowed by
+the config, status changes must follow the configured transitions, and a parent
+must exist. If any change is invalid, no ticket is modified.
+
+  todo set aBc priority=1 type=bug assignee=Bob
+  todo set aBc xYz tags+=perf tags-=ui
+  todo set aBc parent=qRs title="Fix login timeout"
+  todo set aBc due=friday scheduled=+2d`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// IDs come first, then assignments (IDs never contain "=")
+		var ids []string
+		var changes []tickets.FieldChange
+		for _, arg := range args {
+			if !strings.Contains(arg, "=") {
+				if len(changes) > 0 {
+					return fmt.Errorf("invalid assignment %q: expected field=value", arg)
+				}
+				ids = append(ids, arg)
+				continue
+			}
+			c, err := tickets.ParseFieldChange(arg)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, c)
+		}
+
+		if len(ids) == 0 {
+			return fmt.Errorf("no ticket ID given")
+		}
+		if len(changes) == 0 {
+			return fmt.Errorf("no field=value assignment given")
+		}
+
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		updated, created, err := tickets.Set(dir, ids, changes)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range updated {
+			fmt.Printf("Updated %s %s\n", cliID(t.ID), t.Title)
+		}
+		for _, next := range created {
+			printNextOccurrence(next)
+		}
+		if len(updated) == 0 {
+			fmt.Println("No changes")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(setCmd)
+}
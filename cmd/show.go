@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -15,8 +16,10 @@ import (
 var showCmd = &cobra.Command{
 	Use:   "show <id>",
 	Short: "Show a ticket's full details",
-	Long:  `Show the full details of a ticket, including its front matter and description.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Show the full details of a ticket, including its front matter and description.
+
+` + formatHelp,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ref := args[0]
 
@@ -25,6 +28,16 @@ var showCmd = &cobra.Command{
 			return err
 		}
 
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+
+		tmpl, err := templateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
 		ticket, err := tickets.Show(dir, ref)
 		if err != nil {
 			return err
@@ -36,7 +49,15 @@ var showCmd = &cobra.Command{
 			return err
 		}
 
-		rel := tickets.ComputeRelations(ticket, allTickets)
+		if tmpl != nil {
+			return printTemplate(tmpl, []*tickets.Ticket{ticket}, allTickets, cfg)
+		}
+
+		rel := tickets.ComputeRelations(ticket, allTickets, cfg)
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			return printJSON(toShowTicket(ticket, rel))
+		}
 
 		// Build output
 		var b strings.Builder
@@ -90,5 +111,8 @@ func runPager(pager string, content string) error {
 }
 
 func init() {
+	addFormatFlag(showCmd)
+	addJSONFlag(showCmd, "Output the ticket as JSON, with its computed relations")
+	showCmd.MarkFlagsMutuallyExclusive("format", "json")
 	rootCmd.AddCommand(showCmd)
 }
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 	"github.com/spf13/cobra"
 )
@@ -11,8 +13,11 @@ import (
 var startCmd = &cobra.Command{
 	Use:   "start <id>",
 	Short: "Start working on a ticket (set status to in_progress)",
-	Long:  `Set a ticket's status to in_progress.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Set a ticket's status to in_progress.
+
+With --track, or when tracking.auto_start is set in .todo.yaml, also start a
+timer on the ticket (see todo track). A timer that is already running is kept.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
@@ -21,17 +26,36 @@ var startCmd = &cobra.Command{
 			return err
 		}
 
-		title, err := tickets.SetStatus(dir, id, "in_progress")
+		title, _, err := tickets.SetStatus(dir, id, "in_progress")
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Started ticket: %s\n", title)
 
+		cfg, err := config.Load(dir)
+		if err != nil {
+			return err
+		}
+		track, _ := cmd.Flags().GetBool("track")
+		if !track && !cfg.Tracking.AutoStart {
+			return nil
+		}
+
+		t, err := tickets.StartTimer(dir, id)
+		if errors.Is(err, tickets.ErrTimerRunning) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started timer for %s %s\n", cliID(t.ID), t.Title)
+
 		return nil
 	},
 }
 
 func init() {
+	startCmd.Flags().Bool("track", false, "Also start a timer on the ticket")
 	rootCmd.AddCommand(startCmd)
 }
@@ -11,7 +11,7 @@ import (
 var statusCmd = &cobra.Command{
 	Use:   "status <id> <status>",
 	Short: "Set the status of a ticket",
-	Long:  `Set the status of a ticket. Valid statuses: open, in_progress, closed.`,
+	Long:  `Set the status of a ticket. Valid statuses: open, in_progress, closed (or as configured in .todo.yaml).`,
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
@@ -22,12 +22,13 @@ var statusCmd = &cobra.Command{
 			return err
 		}
 
-		title, err := tickets.SetStatus(dir, id, status)
+		title, next, err := tickets.SetStatus(dir, id, status)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Status of %s set to %s\n", title, status)
+		printNextOccurrence(next)
 
 		return nil
 	},
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+// formatHelp describes the --format flag for command help texts.
+const formatHelp = `--format takes a Go text/template, executed once per ticket. It has every ticket
+field (.ID, .Title, .Status, .Type, .Priority, .Assignee, .Created, .Updated,
+.StartedAt, .ClosedAt, .Parent, .ExternalRef, .Design, .Acceptance, .Estimate,
+.Spent, .Due, .Scheduled, .Recur, .Description, .Deps, .Links, .Tags) and the
+computed relations (.ParentTicket, .Blockers, .Blocking, .Children, .Linked).
+Functions: join, ids, status (of a ticket, a related ticket or a status string).
+\t and \n are expanded.
+
+  --format '{{.ID}}\t{{.Priority}}\t{{.Title}}'
+  --format '{{.ID}} {{status .}} blocked by {{join (ids .Blockers) ","}}'`
+
+// templateTicket is the data a --format template is executed with.
+type templateTicket struct {
+	*tickets.Ticket
+	*tickets.TicketRelations
+}
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	// ids returns the IDs of a list of tickets, e.g. {{ids .Blockers}}
+	"ids": func(items []*tickets.Ticket) []string {
+		result := []string{}
+		for _, t := range items {
+			result = append(result, t.ID)
+		}
+		return result
+	},
+	// status returns the status of the ticket, of a related ticket (e.g. in
+	// {{range .Blockers}}) or a status string, or "open" when it is empty
+	"status": func(v any) (string, error) {
+		var status string
+		switch v := v.(type) {
+		case templateTicket:
+			status = v.Status
+		case *tickets.Ticket:
+			status = v.Status
+		case string:
+			status = v
+		default:
+			return "", fmt.Errorf("expected a ticket or a status, got %T", v)
+		}
+		if status == "" {
+			return "open", nil
+		}
+		return status, nil
+	},
+}
+
+// templateEscapes expands the escapes that single-quoted shell strings keep literal.
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// addFormatFlag registers the --format flag on a command.
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().String("format", "", "Format each ticket with a Go template (e.g. '{{.ID}}\\t{{.Title}}')")
+}
+
+// templateFromFlags parses the --format template. Returns nil if the flag is unset.
+func templateFromFlags(cmd *cobra.Command) (*template.Template, error) {
+	format, _ := cmd.Flags().GetString("format")
+	if format == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("format").Funcs(templateFuncs).Option("missingkey=error").Parse(templateEscapes.Replace(format))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printTemplate executes the template for each ticket and prints one result per line.
+// Relations are computed against all tickets.
+func printTemplate(tmpl *template.Template, items []*tickets.Ticket, allTickets []*tickets.Ticket, cfg *config.Config) error {
+	for _, t := range items {
+		var b strings.Builder
+		data := templateTicket{Ticket: t, TicketRelations: tickets.ComputeRelations(t, allTickets, cfg)}
+		if err := tmpl.Execute(&b, data); err != nil {
+			return fmt.Errorf("--format: %w", err)
+		}
+		fmt.Println(b.String())
+	}
+	return nil
+}
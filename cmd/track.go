@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+var trackCmd = &cobra.Command{
+	Use:   "track",
+	Short: "Track time spent on tickets",
+	Long: `Record work sessions on tickets. Each session is stored in the ticket's
+sessions list with its start and end time and the git user.name, and its length
+is added to the ticket's spent field when the timer stops.
+
+  todo track start aBc
+  todo track stop aBc
+  todo report time --since 2026-10-01 --by assignee`,
+}
+
+var trackStartCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start a timer on a ticket",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		t, err := tickets.StartTimer(dir, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Started timer for %s %s\n", cliID(t.ID), t.Title)
+		return nil
+	},
+}
+
+var trackStopCmd = &cobra.Command{
+	Use:   "stop <id>",
+	Short: "Stop the timer on a ticket and add the session to its spent time",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		t, d, err := tickets.StopTimer(dir, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Stopped timer for %s %s: %s (spent %s)\n", cliID(t.ID), t.Title, tickets.FormatEstimate(d), tickets.FormatEstimate(t.SpentDuration()))
+		return nil
+	},
+}
+
+func init() {
+	trackCmd.AddCommand(trackStartCmd)
+	trackCmd.AddCommand(trackStopCmd)
+	rootCmd.AddCommand(trackCmd)
+}
@@ -21,7 +21,7 @@ KEYBINDINGS:
   Ticket List:
     ↑/k ↓/j   Move cursor
     g/G        First/last ticket
-    a          Add new ticket (with defaults: type=task, priority=2)
+    a          Add new ticket (with the configured default type and priority)
     s          Start ticket (set status to in_progress)
     c/d        Close ticket (set status to closed)
     r          Reopen ticket (set status to open)
@@ -33,7 +33,7 @@ KEYBINDINGS:
     1          All open tickets
     2          Ready tickets (all deps closed)
     3          Blocked tickets (has unclosed deps)
-    4          Closed tickets (sorted by last modified)
+    4          Closed tickets (sorted by close time)
 
   Detail Panel:
     ↑/k ↓/j   Scroll content
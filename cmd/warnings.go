@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/juanibiapina/todo/internal/tickets"
+	"github.com/spf13/cobra"
+)
+
+// listTickets returns all tickets and prints a warning to stderr for each
+// ticket file that couldn't be parsed, so broken files don't silently vanish.
+func listTickets(cmd *cobra.Command, dir string) ([]*tickets.Ticket, error) {
+	allItems, warnings, err := tickets.ListWithWarnings(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range warnings {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", formatParseWarning(dir, w))
+	}
+
+	return allItems, nil
+}
+
+// formatParseWarning formats a parse warning with the file path relative to dir.
+// Example: docs/tickets/aBc.md: missing frontmatter closing delimiter
+func formatParseWarning(dir string, w tickets.ParseWarning) string {
+	path := w.Path
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		path = rel
+	}
+	return fmt.Sprintf("%s: %v", path, w.Err)
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the repository-wide config file, relative to the project directory.
+const FileName = ".todo.yaml"
+
+// DefaultTicketsDir is the tickets directory used when the config doesn't set one.
+const DefaultTicketsDir = "docs/tickets"
+
+// Config holds repository-wide settings for tickets.
+type Config struct {
+	TicketsDir   string         `yaml:"tickets_dir"`
+	Types        []string       `yaml:"types"`
+	Statuses     []string       `yaml:"statuses"`
+	DoneStatuses []string       `yaml:"done_statuses"`
+	Transitions  Transitions    `yaml:"transitions"`
+	Priority     PriorityRange  `yaml:"priority"`
+	Defaults     TicketDefaults `yaml:"defaults"`
+	Tracking     Tracking       `yaml:"tracking"`
+}
+
+// Transitions maps each status to the statuses it may move to.
+// An empty map allows every transition.
+type Transitions map[string][]string
+
+// PriorityRange is the inclusive range of allowed priorities.
+type PriorityRange struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
+// TicketDefaults holds the values applied to new tickets when not given explicitly.
+type TicketDefaults struct {
+	Type     string `yaml:"type"`
+	Priority int    `yaml:"priority"`
+	Assignee string `yaml:"assignee"`
+}
+
+// Tracking holds the time tracking settings.
+type Tracking struct {
+	// AutoStart starts a timer when a ticket is started with `todo start`.
+	AutoStart bool `yaml:"auto_start"`
+}
+
+// Default returns the built-in configuration used when no config file exists.
+func Default() *Config {
+	return &Config{
+		TicketsDir:   DefaultTicketsDir,
+		Types:        []string{"bug", "feature", "task", "epic", "chore"},
+		Statuses:     []string{"open", "in_progress", "closed"},
+		DoneStatuses: []string{"closed"},
+		Priority:     PriorityRange{Min: 0, Max: 4},
+		Defaults: TicketDefaults{
+			Type:     "task",
+			Priority: 2,
+		},
+	}
+}
+
+// Path returns the path to the config file in the given directory.
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// loadedFile is a parsed config file and the size and modification time it had.
+type loadedFile struct {
+	size    int64
+	modTime time.Time
+	cfg     *Config
+}
+
+// loaded caches parsed config files by path, so a command that loads the config
+// in several places reads and validates the file once.
+var (
+	loadedMu sync.Mutex
+	loaded   = make(map[string]loadedFile)
+)
+
+// Load reads the config file from the given directory.
+// Fields missing from the file keep their default values.
+// Returns the default config if the file doesn't exist.
+// The file is parsed again only when its size or modification time changes, and
+// the returned config is shared between callers, so it must not be modified.
+func Load(dir string) (*Config, error) {
+	path := Path(dir)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Default(), nil
+		}
+		return nil, err
+	}
+
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+	if f, ok := loaded[path]; ok && f.size == info.Size() && f.modTime.Equal(info.ModTime()) {
+		return f.cfg, nil
+	}
+
+	cfg := Default()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", FileName, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", FileName, err)
+	}
+
+	loaded[path] = loadedFile{size: info.Size(), modTime: info.ModTime(), cfg: cfg}
+	return cfg, nil
+}
+
+// validate checks that the config is internally consistent.
+func (c *Config) validate() error {
+	if c.TicketsDir == "" {
+		return fmt.Errorf("tickets_dir must not be empty")
+	}
+	if len(c.Types) == 0 {
+		return fmt.Errorf("types must not be empty")
+	}
+	if len(c.Statuses) == 0 {
+		return fmt.Errorf("statuses must not be empty")
+	}
+	// New tickets, imported ones and tickets without a status are open
+	if !c.ValidStatus("open") {
+		return fmt.Errorf("statuses must include \"open\", the status of new tickets")
+	}
+	if len(c.DoneStatuses) == 0 {
+		return fmt.Errorf("done_statuses must not be empty")
+	}
+	for _, s := range c.DoneStatuses {
+		if !c.ValidStatus(s) {
+			return fmt.Errorf("done status %q is not one of %s", s, strings.Join(c.Statuses, ", "))
+		}
+	}
+	for from, targets := range c.Transitions {
+		if !c.ValidStatus(from) {
+			return fmt.Errorf("transition from unknown status %q", from)
+		}
+		for _, to := range targets {
+			if !c.ValidStatus(to) {
+				return fmt.Errorf("transition from %q to unknown status %q", from, to)
+			}
+		}
+	}
+	if c.Priority.Min > c.Priority.Max {
+		return fmt.Errorf("priority min %d is greater than max %d", c.Priority.Min, c.Priority.Max)
+	}
+	if !c.ValidType(c.Defaults.Type) {
+		return fmt.Errorf("default type %q is not one of %s", c.Defaults.Type, strings.Join(c.Types, ", "))
+	}
+	if !c.ValidPriority(c.Defaults.Priority) {
+		return fmt.Errorf("default priority %d is not between %d and %d", c.Defaults.Priority, c.Priority.Min, c.Priority.Max)
+	}
+	return nil
+}
+
+// ValidType reports whether t is an allowed ticket type.
+func (c *Config) ValidType(t string) bool {
+	for _, v := range c.Types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidStatus reports whether s is an allowed ticket status.
+func (c *Config) ValidStatus(s string) bool {
+	for _, v := range c.Statuses {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDone reports whether s is a done status.
+// Tickets in a done status don't block other tickets.
+func (c *Config) IsDone(s string) bool {
+	for _, v := range c.DoneStatuses {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// DoneStatus returns the status used when a ticket is marked as done.
+func (c *Config) DoneStatus() string {
+	return c.DoneStatuses[0]
+}
+
+// CheckTransition returns an error if a ticket may not move from one status to another.
+// An empty from status is treated as "open". Staying in the same status is always allowed.
+func (c *Config) CheckTransition(from, to string) error {
+	if from == "" {
+		from = "open"
+	}
+	if from == to || len(c.Transitions) == 0 {
+		return nil
+	}
+
+	allowed := c.Transitions[from]
+	for _, s := range allowed {
+		if s == to {
+			return nil
+		}
+	}
+
+	if len(allowed) == 0 {
+		return fmt.Errorf("cannot change status from %q to %q: no transitions allowed from %q", from, to, from)
+	}
+	return fmt.Errorf("cannot change status from %q to %q (allowed: %s)", from, to, strings.Join(allowed, ", "))
+}
+
+// ValidPriority reports whether p is within the allowed priority range.
+func (c *Config) ValidPriority(p int) bool {
+	return p >= c.Priority.Min && p <= c.Priority.Max
+}
+
+// CheckType returns an error if t is not an allowed ticket type.
+func (c *Config) CheckType(t string) error {
+	if !c.ValidType(t) {
+		return fmt.Errorf("invalid type %q: must be one of %s", t, strings.Join(c.Types, ", "))
+	}
+	return nil
+}
+
+// CheckStatus returns an error if s is not an allowed ticket status.
+func (c *Config) CheckStatus(s string) error {
+	if !c.ValidStatus(s) {
+		return fmt.Errorf("invalid status: %q (valid: %s)", s, strings.Join(c.Statuses, ", "))
+	}
+	return nil
+}
+
+// CheckPriority returns an error if p is outside the allowed priority range.
+func (c *Config) CheckPriority(p int) error {
+	if !c.ValidPriority(p) {
+		return fmt.Errorf("invalid priority %d: must be between %d and %d", p, c.Priority.Min, c.Priority.Max)
+	}
+	return nil
+}
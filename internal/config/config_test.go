@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestLoadMissingFileReturnsDefaults(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.TicketsDir != DefaultTicketsDir {
+		t.Errorf("TicketsDir = %q, want %q", cfg.TicketsDir, DefaultTicketsDir)
+	}
+	if !cfg.ValidType("task") || cfg.ValidType("spike") {
+		t.Errorf("unexpected default types: %v", cfg.Types)
+	}
+	if !cfg.ValidStatus("in_progress") || cfg.ValidStatus("review") {
+		t.Errorf("unexpected default statuses: %v", cfg.Statuses)
+	}
+	if cfg.Defaults.Type != "task" || cfg.Defaults.Priority != 2 {
+		t.Errorf("defaults = %+v", cfg.Defaults)
+	}
+}
+
+func TestLoadOverridesFields(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, `tickets_dir: tickets
+types: [bug, spike]
+statuses: [open, in_progress, review, closed, wontfix]
+priority:
+  max: 9
+defaults:
+  type: spike
+  priority: 5
+  assignee: Bob
+tracking:
+  auto_start: true
+`)
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.TicketsDir != "tickets" {
+		t.Errorf("TicketsDir = %q", cfg.TicketsDir)
+	}
+	if !cfg.ValidType("spike") || cfg.ValidType("task") {
+		t.Errorf("types = %v", cfg.Types)
+	}
+	if !cfg.ValidStatus("review") || !cfg.ValidStatus("wontfix") {
+		t.Errorf("statuses = %v", cfg.Statuses)
+	}
+	// Min is not set in the file and keeps its default
+	if cfg.Priority.Min != 0 || cfg.Priority.Max != 9 {
+		t.Errorf("priority = %+v", cfg.Priority)
+	}
+	if cfg.Defaults.Type != "spike" || cfg.Defaults.Priority != 5 || cfg.Defaults.Assignee != "Bob" {
+		t.Errorf("defaults = %+v", cfg.Defaults)
+	}
+	if !cfg.Tracking.AutoStart {
+		t.Errorf("tracking = %+v", cfg.Tracking)
+	}
+}
+
+func TestLoadRereadsChangedFile(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "tickets_dir: one\n")
+
+	first, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	again, _ := Load(dir)
+	if again != first {
+		t.Error("an unchanged file should not be parsed again")
+	}
+
+	writeConfig(t, dir, "tickets_dir: second\n")
+	changed, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if changed.TicketsDir != "second" {
+		t.Errorf("TicketsDir = %q, want the changed value", changed.TicketsDir)
+	}
+}
+
+func TestLoadPartialFileKeepsDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "statuses: [open, review, closed]\n")
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.TicketsDir != DefaultTicketsDir {
+		t.Errorf("TicketsDir = %q", cfg.TicketsDir)
+	}
+	if cfg.Defaults.Type != "task" {
+		t.Errorf("default type = %q", cfg.Defaults.Type)
+	}
+	if cfg.ValidStatus("in_progress") {
+		t.Error("in_progress should not be valid when statuses are overridden")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"bad yaml", "types: [unclosed\n", "invalid config"},
+		{"empty types", "types: []\n", "types must not be empty"},
+		{"empty statuses", "statuses: []\n", "statuses must not be empty"},
+		{"no open status", "statuses: [todo, doing, closed]\n", `statuses must include "open"`},
+		{"inverted range", "priority: {min: 3, max: 1}\n", "greater than max"},
+		{"unknown default type", "defaults: {type: spike}\n", "default type"},
+		{"default priority out of range", "defaults: {priority: 7}\n", "default priority"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeConfig(t, dir, tt.content)
+
+			_, err := Load(dir)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	cfg := Default()
+
+	if err := cfg.CheckType("bug"); err != nil {
+		t.Errorf("CheckType(bug): %v", err)
+	}
+	if err := cfg.CheckType("spike"); err == nil || !strings.Contains(err.Error(), "invalid type") {
+		t.Errorf("CheckType(spike) = %v", err)
+	}
+	if err := cfg.CheckStatus("review"); err == nil || !strings.Contains(err.Error(), "invalid status") {
+		t.Errorf("CheckStatus(review) = %v", err)
+	}
+	if err := cfg.CheckPriority(4); err != nil {
+		t.Errorf("CheckPriority(4): %v", err)
+	}
+	if err := cfg.CheckPriority(5); err == nil || !strings.Contains(err.Error(), "invalid priority") {
+		t.Errorf("CheckPriority(5) = %v", err)
+	}
+}
+
+func TestLoadWorkflow(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, `statuses: [open, in_progress, review, closed, wontfix]
+done_statuses: [closed, wontfix]
+transitions:
+  open: [in_progress, wontfix]
+  in_progress: [open, review]
+  review: [in_progress, closed]
+  closed: [open]
+`)
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.IsDone("wontfix") || !cfg.IsDone("closed") || cfg.IsDone("review") {
+		t.Errorf("done statuses = %v", cfg.DoneStatuses)
+	}
+	if cfg.DoneStatus() != "closed" {
+		t.Errorf("DoneStatus = %q, want %q", cfg.DoneStatus(), "closed")
+	}
+}
+
+func TestLoadInvalidWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"done status unknown", "done_statuses: [done]\n", `done status "done"`},
+		{"done statuses empty", "done_statuses: []\n", "done_statuses must not be empty"},
+		{"transition from unknown", "transitions: {review: [open]}\n", `unknown status "review"`},
+		{"transition to unknown", "transitions: {open: [review]}\n", `unknown status "review"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeConfig(t, dir, tt.content)
+
+			_, err := Load(dir)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	cfg := Default()
+	cfg.Transitions = Transitions{
+		"open":        {"in_progress", "closed"},
+		"in_progress": {"open", "closed"},
+		"closed":      {"open"},
+	}
+
+	if err := cfg.CheckTransition("open", "in_progress"); err != nil {
+		t.Errorf("open -> in_progress: %v", err)
+	}
+	if err := cfg.CheckTransition("", "in_progress"); err != nil {
+		t.Errorf("empty status should be treated as open: %v", err)
+	}
+	if err := cfg.CheckTransition("closed", "closed"); err != nil {
+		t.Errorf("same status should be allowed: %v", err)
+	}
+
+	err := cfg.CheckTransition("closed", "in_progress")
+	if err == nil {
+		t.Fatal("closed -> in_progress should be forbidden")
+	}
+	if !strings.Contains(err.Error(), "allowed: open") {
+		t.Errorf("error should list allowed states: %v", err)
+	}
+}
+
+func TestCheckTransitionNoneAllowed(t *testing.T) {
+	cfg := Default()
+	cfg.Transitions = Transitions{"open": {"closed"}}
+
+	err := cfg.CheckTransition("closed", "open")
+	if err == nil || !strings.Contains(err.Error(), "no transitions allowed") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestCheckTransitionUnrestricted(t *testing.T) {
+	cfg := Default()
+
+	if err := cfg.CheckTransition("closed", "in_progress"); err != nil {
+		t.Errorf("without transitions every move is allowed: %v", err)
+	}
+}
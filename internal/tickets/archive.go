@@ -0,0 +1,136 @@
+package tickets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// ArchiveDirName is the name of the archive directory inside the tickets directory.
+const ArchiveDirName = "archive"
+
+// ArchiveDirPath returns the path to the archive directory in the given directory.
+func ArchiveDirPath(dir string) (string, error) {
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(ticketsDir, ArchiveDirName), nil
+}
+
+// ListArchived returns all archived tickets. Files that can't be parsed are skipped.
+func ListArchived(dir string) ([]*Ticket, error) {
+	archiveDir, err := ArchiveDirPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, _, err := listEntriesIn(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets []*Ticket
+	for _, e := range entries {
+		tickets = append(tickets, e.ticket)
+	}
+	return tickets, nil
+}
+
+// archivedIDs returns the IDs of all files in the archive directory,
+// including files that can't be parsed.
+func archivedIDs(dir string) (map[string]bool, error) {
+	archiveDir, err := ArchiveDirPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, warnings, err := listEntriesIn(archiveDir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, e := range entries {
+		ids[e.id] = true
+	}
+	for _, w := range warnings {
+		ids[w.ID] = true
+	}
+	return ids, nil
+}
+
+// Archive moves done tickets into the archive directory and returns them.
+// With no IDs, every done ticket is archived. Given IDs must refer to done tickets.
+// Archived tickets are left out of List but can still be read by Show.
+func Archive(dir string, ids []string) ([]*Ticket, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var toArchive []ticketEntry
+	if len(ids) == 0 {
+		entries, _, err := listEntries(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if cfg.IsDone(e.ticket.Status) {
+				toArchive = append(toArchive, e)
+			}
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			path, err := findTicketFile(dir, id)
+			if err != nil {
+				return nil, err
+			}
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			t, err := parseFile(path)
+			if err != nil {
+				return nil, err
+			}
+			if !cfg.IsDone(t.Status) {
+				status := t.Status
+				if status == "" {
+					status = "open"
+				}
+				return nil, fmt.Errorf("cannot archive %s: status %q is not a done status", t.ID, status)
+			}
+			toArchive = append(toArchive, ticketEntry{id: fileID(path), path: path, ticket: t})
+		}
+	}
+
+	if len(toArchive) == 0 {
+		return nil, nil
+	}
+
+	archiveDir, err := ArchiveDirPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return nil, err
+	}
+
+	var archived []*Ticket
+	for _, e := range toArchive {
+		if err := os.Rename(e.path, filepath.Join(archiveDir, ticketFileName(e.id))); err != nil {
+			return archived, err
+		}
+		archived = append(archived, e.ticket)
+	}
+
+	return archived, nil
+}
@@ -0,0 +1,134 @@
+package tickets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArchiveAllDone(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Open"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Closed", Status: "closed"})
+
+	archived, err := Archive(dir, nil)
+	if err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != "bbb" {
+		t.Fatalf("expected only bbb archived, got %v", archived)
+	}
+
+	if _, err := os.Stat(filepath.Join(mustArchiveDirPath(t, dir), "bbb.md")); err != nil {
+		t.Errorf("expected archived file: %v", err)
+	}
+
+	// List ignores the archive
+	tickets, _ := List(dir)
+	if len(tickets) != 1 || tickets[0].ID != "aaa" {
+		t.Errorf("List = %v, want only aaa", tickets)
+	}
+
+	// ListArchived and Show can still read it
+	archivedTickets, err := ListArchived(dir)
+	if err != nil {
+		t.Fatalf("ListArchived: %v", err)
+	}
+	if len(archivedTickets) != 1 || archivedTickets[0].ID != "bbb" {
+		t.Errorf("ListArchived = %v, want only bbb", archivedTickets)
+	}
+	shown, err := Show(dir, "bbb")
+	if err != nil {
+		t.Fatalf("Show archived: %v", err)
+	}
+	if shown.Title != "Closed" {
+		t.Errorf("title = %q, want Closed", shown.Title)
+	}
+}
+
+func TestArchiveByIDRequiresDone(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Open"})
+
+	_, err := Archive(dir, []string{"aaa"})
+	if err == nil {
+		t.Fatal("expected error archiving an open ticket")
+	}
+	if !strings.Contains(err.Error(), `status "open" is not a done status`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestArchivedIDs(t *testing.T) {
+	dir := tempDir(t)
+	os.MkdirAll(mustArchiveDirPath(t, dir), 0755)
+	os.WriteFile(filepath.Join(mustArchiveDirPath(t, dir), "aaa.md"), []byte("---\nid: aaa\n---\n# Old\n"), 0644)
+
+	ids, err := archivedIDs(dir)
+	if err != nil {
+		t.Fatalf("archivedIDs: %v", err)
+	}
+	if !ids["aaa"] {
+		t.Errorf("expected aaa in archived IDs, got %v", ids)
+	}
+}
+
+func TestShowPrefersActiveOverArchive(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	os.MkdirAll(mustArchiveDirPath(t, dir), 0755)
+
+	writeFile(dir, &Ticket{ID: "abc", Title: "Active"})
+	os.WriteFile(filepath.Join(mustArchiveDirPath(t, dir), "abd.md"), []byte("---\nid: abd\n---\n# Archived\n"), 0644)
+
+	// "ab" matches only the active ticket in the tickets directory
+	shown, err := Show(dir, "ab")
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if shown.ID != "abc" {
+		t.Errorf("Show = %s, want abc", shown.ID)
+	}
+}
+
+func TestGetReportsArchivedTickets(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	os.MkdirAll(mustArchiveDirPath(t, dir), 0755)
+
+	writeFile(dir, &Ticket{ID: "abc", Title: "Active"})
+	os.WriteFile(filepath.Join(mustArchiveDirPath(t, dir), "xyz.md"), []byte("---\nid: xyz\n---\n# Archived\n"), 0644)
+
+	if got, err := Get(dir, "ab"); err != nil || got.ID != "abc" {
+		t.Errorf("Get(ab) = %v, %v, want abc", got, err)
+	}
+	if _, err := Get(dir, "xyz"); err == nil || !strings.Contains(err.Error(), "ticket xyz is archived") {
+		t.Errorf("Get(xyz) error = %v, want it to report the archived ticket", err)
+	}
+	if _, err := Get(dir, "nope"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Get(nope) error = %v, want not found", err)
+	}
+}
+
+func TestCheckArchivedTargetIsNotDangling(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Main", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Done", Status: "closed"})
+
+	if _, err := Archive(dir, nil); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, nil)
+}
@@ -0,0 +1,310 @@
+package tickets
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// ProblemKind identifies the kind of integrity problem found by Check.
+type ProblemKind string
+
+const (
+	ProblemUnparseable   ProblemKind = "unparseable"
+	ProblemIDMismatch    ProblemKind = "id-mismatch"
+	ProblemDuplicateID   ProblemKind = "duplicate-id"
+	ProblemDanglingDep   ProblemKind = "dangling-dep"
+	ProblemDanglingLink  ProblemKind = "dangling-link"
+	ProblemMissingParent ProblemKind = "missing-parent"
+	ProblemOneWayLink    ProblemKind = "one-way-link"
+	ProblemDepCycle      ProblemKind = "dep-cycle"
+)
+
+// Problem is an integrity problem in the tickets directory.
+// Ticket IDs are taken from filenames, since that's how tickets are looked up.
+type Problem struct {
+	Kind    ProblemKind
+	ID      string // ticket the problem was found in
+	Ref     string // referenced ticket ID, for reference problems
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.ID, p.Message)
+}
+
+// Fixable reports whether Fix can repair the problem.
+// Unparseable files and dependency cycles need a human decision.
+func (p Problem) Fixable() bool {
+	switch p.Kind {
+	case ProblemUnparseable, ProblemDepCycle:
+		return false
+	}
+	return true
+}
+
+// Check reports integrity problems in the tickets directory: unparseable files,
+// frontmatter IDs that don't match the filename, duplicate IDs, deps, links and
+// parents pointing at tickets that don't exist, one-directional links and
+// dependency cycles among tickets that are not done.
+func Check(dir string) ([]Problem, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, warnings, err := listEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := archivedIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return findProblems(entries, warnings, archived, cfg), nil
+}
+
+// Fix repairs every fixable problem reported by Check and returns the problems it fixed:
+// frontmatter IDs are set to the filename (which also resolves duplicate IDs),
+// dangling deps, links and parents are removed, and missing back-links are added.
+// Each repair is recorded in the ticket's history.
+func Fix(dir string) ([]Problem, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	entries, warnings, err := listEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := archivedIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*Ticket)
+	for _, e := range entries {
+		byID[e.id] = e.ticket
+	}
+
+	var fixed []Problem
+	changed := make(map[string]bool)
+
+	for _, p := range findProblems(entries, warnings, archived, cfg) {
+		if !p.Fixable() {
+			continue
+		}
+
+		// A ref listed twice is reported twice but only needs one fix
+		t := byID[p.ID]
+		switch p.Kind {
+		case ProblemIDMismatch:
+			recordChange(actor, t, "id", t.ID, p.ID)
+			t.ID = p.ID
+			changed[p.ID] = true
+		case ProblemDanglingDep:
+			if !containsString(t.Deps, p.Ref) {
+				break
+			}
+			t.Deps = removeString(t.Deps, p.Ref)
+			recordChange(actor, t, "deps", p.Ref, "")
+			changed[p.ID] = true
+		case ProblemDanglingLink:
+			if !containsString(t.Links, p.Ref) {
+				break
+			}
+			t.Links = removeString(t.Links, p.Ref)
+			recordChange(actor, t, "links", p.Ref, "")
+			changed[p.ID] = true
+		case ProblemMissingParent:
+			t.Parent = ""
+			recordChange(actor, t, "parent", p.Ref, "")
+			changed[p.ID] = true
+		case ProblemOneWayLink:
+			other := byID[p.Ref]
+			if containsString(other.Links, p.ID) {
+				break
+			}
+			other.Links = append(other.Links, p.ID)
+			recordChange(actor, other, "links", "", p.ID)
+			changed[p.Ref] = true
+		case ProblemDuplicateID:
+			// Resolved by the id-mismatch fixes of the files involved
+		}
+
+		fixed = append(fixed, p)
+	}
+
+	for _, e := range entries {
+		if !changed[e.id] {
+			continue
+		}
+		// Write to the file the ticket was read from, even if its frontmatter ID was wrong
+		if err := atomicWriteFile(e.path, []byte(e.ticket.FullString())); err != nil {
+			return nil, err
+		}
+	}
+
+	return fixed, nil
+}
+
+// findProblems checks parsed ticket files for integrity problems.
+// Archived tickets and tickets in files that can't be parsed count as existing,
+// so references to them are not reported as dangling.
+func findProblems(entries []ticketEntry, warnings []ParseWarning, archived map[string]bool, cfg *config.Config) []Problem {
+	var problems []Problem
+
+	exists := make(map[string]bool)
+	for id := range archived {
+		exists[id] = true
+	}
+	for _, e := range entries {
+		exists[e.id] = true
+	}
+	for _, w := range warnings {
+		if errors.Is(w.Err, errMissingTitle) {
+			continue // parsed fine, reported by doctor
+		}
+		exists[w.ID] = true
+		problems = append(problems, Problem{
+			Kind:    ProblemUnparseable,
+			ID:      w.ID,
+			Message: fmt.Sprintf("cannot parse file: %v", w.Err),
+		})
+	}
+
+	byID := make(map[string]*Ticket)
+	fileIDs := make(map[string][]string) // frontmatter ID -> filename IDs
+	for _, e := range entries {
+		byID[e.id] = e.ticket
+		fileIDs[e.ticket.ID] = append(fileIDs[e.ticket.ID], e.id)
+	}
+
+	for _, e := range entries {
+		t := e.ticket
+
+		if t.ID != e.id {
+			msg := fmt.Sprintf("frontmatter id %q does not match filename", t.ID)
+			if t.ID == "" {
+				msg = "frontmatter id is missing"
+			}
+			problems = append(problems, Problem{Kind: ProblemIDMismatch, ID: e.id, Message: msg})
+		}
+
+		for _, depID := range t.Deps {
+			if !exists[depID] {
+				problems = append(problems, Problem{
+					Kind:    ProblemDanglingDep,
+					ID:      e.id,
+					Ref:     depID,
+					Message: fmt.Sprintf("dep %s does not exist", depID),
+				})
+			}
+		}
+
+		if t.Parent != "" && !exists[t.Parent] {
+			problems = append(problems, Problem{
+				Kind:    ProblemMissingParent,
+				ID:      e.id,
+				Ref:     t.Parent,
+				Message: fmt.Sprintf("parent %s does not exist", t.Parent),
+			})
+		}
+
+		for _, linkID := range t.Links {
+			if !exists[linkID] {
+				problems = append(problems, Problem{
+					Kind:    ProblemDanglingLink,
+					ID:      e.id,
+					Ref:     linkID,
+					Message: fmt.Sprintf("link %s does not exist", linkID),
+				})
+				continue
+			}
+
+			// The other side may be unparseable, in which case its links are unknown
+			other, ok := byID[linkID]
+			if ok && !containsString(other.Links, e.id) {
+				problems = append(problems, Problem{
+					Kind:    ProblemOneWayLink,
+					ID:      e.id,
+					Ref:     linkID,
+					Message: fmt.Sprintf("links to %s but %s does not link back", linkID, linkID),
+				})
+			}
+		}
+	}
+
+	// Duplicate frontmatter IDs, in a stable order
+	var dupIDs []string
+	for id, files := range fileIDs {
+		if id != "" && len(files) > 1 {
+			dupIDs = append(dupIDs, id)
+		}
+	}
+	sort.Strings(dupIDs)
+	for _, id := range dupIDs {
+		var names []string
+		for _, f := range fileIDs[id] {
+			names = append(names, ticketFileName(f))
+		}
+		problems = append(problems, Problem{
+			Kind:    ProblemDuplicateID,
+			ID:      id,
+			Message: fmt.Sprintf("duplicate id in %s", strings.Join(names, ", ")),
+		})
+	}
+
+	// Dependency cycles among tickets that are not done
+	ticketMap := make(map[string]*Ticket)
+	for id, t := range byID {
+		if !cfg.IsDone(t.Status) {
+			ticketMap[id] = t
+		}
+	}
+	for _, cycle := range findCycles(ticketMap) {
+		problems = append(problems, Problem{
+			Kind:    ProblemDepCycle,
+			ID:      cycle[0],
+			Message: fmt.Sprintf("dependency cycle %s -> %s", strings.Join(cycle, " -> "), cycle[0]),
+		})
+	}
+
+	return problems
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// removeString returns list without any occurrence of s.
+func removeString(list []string, s string) []string {
+	var result []string
+	for _, v := range list {
+		if v != s {
+			result = append(result, v)
+		}
+	}
+	return result
+}
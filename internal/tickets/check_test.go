@@ -0,0 +1,202 @@
+package tickets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// problemKinds returns "ID kind" strings for easy comparison.
+func problemKinds(problems []Problem) []string {
+	var result []string
+	for _, p := range problems {
+		result = append(result, p.ID+" "+string(p.Kind))
+	}
+	return result
+}
+
+func assertProblems(t *testing.T, problems []Problem, want []string) {
+	t.Helper()
+	got := problemKinds(problems)
+	if len(got) != len(want) {
+		t.Fatalf("problems = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("problem %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCheckNoProblems(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "First", Deps: []string{"bbb"}, Links: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second", Parent: "aaa", Links: []string{"aaa"}})
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, nil)
+}
+
+func TestCheckDanglingReferences(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Main", Deps: []string{"zzz"}, Parent: "yyy", Links: []string{"xxx"}})
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, []string{
+		"aaa dangling-dep",
+		"aaa missing-parent",
+		"aaa dangling-link",
+	})
+	if problems[0].Ref != "zzz" || problems[0].Message != "dep zzz does not exist" {
+		t.Errorf("unexpected dangling dep problem: %+v", problems[0])
+	}
+}
+
+func TestCheckOneWayLink(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "First", Links: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second"})
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, []string{"aaa one-way-link"})
+	if problems[0].Message != "links to bbb but bbb does not link back" {
+		t.Errorf("message = %q", problems[0].Message)
+	}
+}
+
+func TestCheckIDMismatchAndDuplicate(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Original"})
+	content := "---\nid: aaa\n---\n# Copy\n"
+	os.WriteFile(filepath.Join(mustDirPath(t, dir), "bbb.md"), []byte(content), 0644)
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, []string{"bbb id-mismatch", "aaa duplicate-id"})
+	if problems[1].Message != "duplicate id in aaa.md, bbb.md" {
+		t.Errorf("message = %q", problems[1].Message)
+	}
+}
+
+func TestCheckUnparseableTargetIsNotDangling(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Main", Deps: []string{"bad"}, Links: []string{"bad"}})
+	os.WriteFile(filepath.Join(mustDirPath(t, dir), "bad.md"), []byte("broken"), 0644)
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, []string{"bad unparseable"})
+	if problems[0].Fixable() {
+		t.Error("unparseable file should not be fixable")
+	}
+}
+
+func TestCheckCycles(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "First", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second", Deps: []string{"aaa"}})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Done", Status: "closed", Deps: []string{"ddd"}})
+	writeFile(dir, &Ticket{ID: "ddd", Title: "Other", Deps: []string{"ccc"}})
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, []string{"aaa dep-cycle"})
+	if problems[0].Message != "dependency cycle aaa -> bbb -> aaa" {
+		t.Errorf("message = %q", problems[0].Message)
+	}
+}
+
+func TestFix(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Main", Deps: []string{"zzz", "bbb"}, Parent: "yyy", Links: []string{"bbb", "xxx"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second"})
+	os.WriteFile(filepath.Join(mustDirPath(t, dir), "ccc.md"), []byte("---\nid: aaa\n---\n# Copy\n"), 0644)
+
+	fixed, err := Fix(dir)
+	if err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if len(fixed) != 6 {
+		t.Errorf("fixed %d problems, want 6: %v", len(fixed), problemKinds(fixed))
+	}
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	assertProblems(t, problems, nil)
+
+	main, _ := Show(dir, "aaa")
+	if len(main.Deps) != 1 || main.Deps[0] != "bbb" {
+		t.Errorf("deps = %v, want [bbb]", main.Deps)
+	}
+	if main.Parent != "" {
+		t.Errorf("parent = %q, want empty", main.Parent)
+	}
+	if len(main.Links) != 1 || main.Links[0] != "bbb" {
+		t.Errorf("links = %v, want [bbb]", main.Links)
+	}
+	if len(main.History) != 3 {
+		t.Errorf("expected 3 history entries, got %d", len(main.History))
+	}
+
+	second, _ := Show(dir, "bbb")
+	if len(second.Links) != 1 || second.Links[0] != "aaa" {
+		t.Errorf("back-link not added: %v", second.Links)
+	}
+
+	copied, _ := Show(dir, "ccc")
+	if copied.ID != "ccc" || copied.Title != "Copy" {
+		t.Errorf("copy = %+v, want id ccc", copied)
+	}
+}
+
+func TestFixLeavesCycles(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "First", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second", Deps: []string{"aaa"}})
+
+	fixed, err := Fix(dir)
+	if err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if len(fixed) != 0 {
+		t.Errorf("expected nothing fixed, got %v", problemKinds(fixed))
+	}
+
+	first, _ := Show(dir, "aaa")
+	if len(first.Deps) != 1 || first.History != nil {
+		t.Errorf("ticket should be untouched: %+v", first)
+	}
+}
@@ -4,24 +4,18 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
 )
 
-// DepCycles detects dependency cycles among open (non-closed) tickets.
+// DepCycles detects dependency cycles among tickets that are not done.
 // Returns a formatted string of all cycles found, or empty string if none.
 func DepCycles(dir string) (string, error) {
-	allTickets, err := List(dir)
+	ticketMap, err := openTicketMap(dir)
 	if err != nil {
 		return "", err
 	}
 
-	// Build ticket map and adjacency graph, excluding closed tickets
-	ticketMap := make(map[string]*Ticket)
-	for _, t := range allTickets {
-		if t.Status != "closed" {
-			ticketMap[t.ID] = t
-		}
-	}
-
 	cycles := findCycles(ticketMap)
 
 	if len(cycles) == 0 {
@@ -31,6 +25,46 @@ func DepCycles(dir string) (string, error) {
 	return formatCycles(cycles, ticketMap), nil
 }
 
+// FindDepCycles returns the dependency cycles among tickets that are not done,
+// each as the list of its member tickets starting at the smallest ID.
+func FindDepCycles(dir string) ([][]*Ticket, error) {
+	ticketMap, err := openTicketMap(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result [][]*Ticket
+	for _, cycle := range findCycles(ticketMap) {
+		var members []*Ticket
+		for _, id := range cycle {
+			members = append(members, ticketMap[id])
+		}
+		result = append(result, members)
+	}
+	return result, nil
+}
+
+// openTicketMap loads the tickets that are not done, keyed by ID.
+func openTicketMap(dir string) (map[string]*Ticket, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	allTickets, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketMap := make(map[string]*Ticket)
+	for _, t := range allTickets {
+		if !cfg.IsDone(t.Status) {
+			ticketMap[t.ID] = t
+		}
+	}
+	return ticketMap, nil
+}
+
 // findCycles performs DFS-based cycle detection on the dependency graph.
 // Uses 3-color marking: white (unseen), gray (in current path), black (fully explored).
 // Returns deduplicated, normalized cycles.
@@ -53,7 +87,7 @@ func findCycles(ticketMap map[string]*Ticket) [][]string {
 		t, ok := ticketMap[id]
 		if ok {
 			for _, depID := range t.Deps {
-				// Skip deps that point to closed or non-existent tickets
+				// Skip deps that point to done or non-existent tickets
 				if _, exists := ticketMap[depID]; !exists {
 					continue
 				}
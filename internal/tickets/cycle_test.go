@@ -1,6 +1,8 @@
 package tickets
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -97,6 +99,25 @@ func TestDepCyclesClosedExcluded(t *testing.T) {
 	}
 }
 
+func TestDepCyclesDoneStatusExcluded(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("statuses: [open, closed, wontfix]\ndone_statuses: [closed, wontfix]\n"), 0644)
+
+	// aaa -> bbb -> aaa, but bbb is wontfix — a done status breaks the cycle
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Open", Status: "open", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Abandoned", Status: "wontfix", Deps: []string{"aaa"}})
+
+	result, err := DepCycles(dir)
+	if err != nil {
+		t.Fatalf("DepCycles: %v", err)
+	}
+
+	if result != "" {
+		t.Errorf("expected no cycles (done ticket excluded), got:\n%s", result)
+	}
+}
+
 func TestDepCyclesNormalized(t *testing.T) {
 	dir := tempDir(t)
 	EnsureDir(dir)
@@ -152,3 +173,23 @@ func TestDepCyclesWithStatus(t *testing.T) {
 		t.Errorf("got:\n%s\nwant:\n%s", result, expected)
 	}
 }
+
+func TestFindDepCycles(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Third", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second", Deps: []string{"ccc"}})
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Free"})
+
+	cycles, err := FindDepCycles(dir)
+	if err != nil {
+		t.Fatalf("FindDepCycles: %v", err)
+	}
+	if len(cycles) != 1 || len(cycles[0]) != 2 {
+		t.Fatalf("cycles = %v, want one cycle of two tickets", cycles)
+	}
+	if cycles[0][0].ID != "bbb" || cycles[0][1].ID != "ccc" || cycles[0][1].Title != "Third" {
+		t.Errorf("cycle = [%s %s], want [bbb ccc]", cycles[0][0].ID, cycles[0][1].ID)
+	}
+}
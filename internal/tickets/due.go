@@ -0,0 +1,115 @@
+package tickets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of the due and scheduled fields.
+const DateLayout = "2006-01-02"
+
+// relativeDate matches an offset from today like "+3d", "+2w" or "+1m".
+var relativeDate = regexp.MustCompile(`^\+(\d+)(d|w|m)$`)
+
+// ParseDate parses a due or scheduled date relative to now and returns it as
+// YYYY-MM-DD. It accepts a date (2026-10-23), "today", "tomorrow", a weekday
+// ("friday", "fri" or "next friday": the next one after today) and an offset
+// from today ("+3d", "+2w", "+1m" for days, weeks and months).
+func ParseDate(value string, now time.Time) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if d, err := time.Parse(DateLayout, s); err == nil {
+		return d.Format(DateLayout), nil
+	}
+
+	switch s {
+	case "today":
+		return today.Format(DateLayout), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format(DateLayout), nil
+	}
+
+	if m := relativeDate.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "d":
+			return today.AddDate(0, 0, n).Format(DateLayout), nil
+		case "w":
+			return today.AddDate(0, 0, 7*n).Format(DateLayout), nil
+		default:
+			return today.AddDate(0, n, 0).Format(DateLayout), nil
+		}
+	}
+
+	name := strings.TrimPrefix(s, "next ")
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		full := strings.ToLower(wd.String())
+		if name == full || name == full[:3] {
+			days := (int(wd)-int(today.Weekday())+6)%7 + 1
+			return today.AddDate(0, 0, days).Format(DateLayout), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid date %q: expected YYYY-MM-DD, today, tomorrow, a weekday or an offset like +3d, +2w or +1m", value)
+}
+
+// DueDate returns the ticket's parsed due date, or false when it has none or it
+// can't be parsed.
+func (t *Ticket) DueDate() (time.Time, bool) {
+	if t.Due == "" {
+		return time.Time{}, false
+	}
+	d, err := time.Parse(DateLayout, t.Due)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return d, true
+}
+
+// IsOverdue reports whether the ticket's due date is before the day of now.
+// It doesn't look at the status: callers decide which tickets can be overdue.
+func (t *Ticket) IsOverdue(now time.Time) bool {
+	_, ok := t.DueDate()
+	return ok && t.Due < now.Format(DateLayout)
+}
+
+// DueTickets are tickets grouped by due date: overdue, due today, and due in
+// the next 7 days.
+type DueTickets struct {
+	Overdue  []*Ticket
+	Today    []*Ticket
+	ThisWeek []*Ticket
+}
+
+// GroupByDue groups tickets by due date relative to the day of now. Tickets
+// without a due date or due later are left out. Each group is sorted by due
+// date, then priority.
+func GroupByDue(items []*Ticket, now time.Time) *DueTickets {
+	today := now.Format(DateLayout)
+	weekEnd := time.Date(now.Year(), now.Month(), now.Day()+7, 0, 0, 0, 0, time.UTC).Format(DateLayout)
+
+	result := &DueTickets{}
+	for _, t := range items {
+		if _, ok := t.DueDate(); !ok {
+			continue
+		}
+		switch {
+		case t.Due < today:
+			result.Overdue = append(result.Overdue, t)
+		case t.Due == today:
+			result.Today = append(result.Today, t)
+		case t.Due <= weekEnd:
+			result.ThisWeek = append(result.ThisWeek, t)
+		}
+	}
+
+	byDue := []SortKey{{Field: "due"}, {Field: "priority"}}
+	SortTickets(result.Overdue, byDue)
+	SortTickets(result.Today, byDue)
+	SortTickets(result.ThisWeek, byDue)
+	return result
+}
@@ -0,0 +1,118 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC) // a Wednesday
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2026-12-01", "2026-12-01"},
+		{"today", "2026-10-14"},
+		{"Tomorrow", "2026-10-15"},
+		{"friday", "2026-10-16"},
+		{"fri", "2026-10-16"},
+		{"next friday", "2026-10-16"},
+		{"wednesday", "2026-10-21"},
+		{"monday", "2026-10-19"},
+		{"+3d", "2026-10-17"},
+		{"+2w", "2026-10-28"},
+		{"+1m", "2026-11-14"},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDate(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "someday", "+3", "-1d", "2026-13-01"} {
+		if _, err := ParseDate(input, now); err == nil {
+			t.Errorf("ParseDate(%q) should fail", input)
+		}
+	}
+}
+
+func TestGroupByDue(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	items := []*Ticket{
+		{ID: "aaa", Due: "2026-10-10", Priority: 2},
+		{ID: "bbb", Due: "2026-10-14", Priority: 2},
+		{ID: "ccc", Due: "2026-10-21", Priority: 2},
+		{ID: "ddd", Due: "2026-10-22", Priority: 2},
+		{ID: "eee", Due: "2026-10-16", Priority: 2},
+		{ID: "fff", Due: "2026-10-16", Priority: 0},
+		{ID: "ggg"},
+		{ID: "hhh", Due: "soon"},
+	}
+
+	due := GroupByDue(items, now)
+	ids := func(items []*Ticket) string {
+		var result []string
+		for _, t := range items {
+			result = append(result, t.ID)
+		}
+		return strings.Join(result, ",")
+	}
+	if got := ids(due.Overdue); got != "aaa" {
+		t.Errorf("overdue = %q", got)
+	}
+	if got := ids(due.Today); got != "bbb" {
+		t.Errorf("today = %q", got)
+	}
+	if got := ids(due.ThisWeek); got != "fff,eee,ccc" {
+		t.Errorf("this week = %q", got)
+	}
+
+	if !items[0].IsOverdue(now) || items[1].IsOverdue(now) || items[6].IsOverdue(now) || items[7].IsOverdue(now) {
+		t.Error("only aaa should be overdue")
+	}
+}
+
+func TestSortByDue(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", Priority: 0},
+		{ID: "bbb", Due: "2026-10-20", Priority: 2},
+		{ID: "ccc", Due: "2026-10-15", Priority: 3},
+		{ID: "ddd", Due: "2026-10-20", Priority: 1},
+	}
+	SortTickets(items, []SortKey{{Field: "due"}, {Field: "priority"}})
+
+	var got []string
+	for _, t := range items {
+		got = append(got, t.ID)
+	}
+	if strings.Join(got, ",") != "ccc,ddd,bbb,aaa" {
+		t.Errorf("order = %v, want tickets without a due date last", got)
+	}
+}
+
+func TestFilterDue(t *testing.T) {
+	f, err := ParseFilter("due<2026-10-15 has:scheduled")
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	if !f.Match(&Ticket{Due: "2026-10-14", Scheduled: "2026-10-01"}) {
+		t.Error("expected a match for an earlier due date")
+	}
+	if f.Match(&Ticket{Due: "2026-10-15", Scheduled: "2026-10-01"}) || f.Match(&Ticket{Due: "2026-10-14"}) {
+		t.Error("expected no match")
+	}
+
+	if _, err := ParseFilter("due<=+7d"); err != nil {
+		t.Errorf("relative due dates should be accepted in filters: %v", err)
+	}
+	if _, err := ParseFilter("created<=+7d"); err == nil {
+		t.Error("relative dates should only be accepted for due and scheduled")
+	}
+}
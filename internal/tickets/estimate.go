@@ -0,0 +1,83 @@
+package tickets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Working time units for estimates: a day is 8 hours and a week is 5 days.
+const (
+	WorkDay  = 8 * time.Hour
+	WorkWeek = 5 * WorkDay
+)
+
+// estimatePart matches one "<number><unit>" part of an estimate.
+var estimatePart = regexp.MustCompile(`^(\d+(?:\.\d+)?)(w|d|h|m|s)`)
+
+// ParseEstimate parses an amount of work like "4h", "1.5d", "1d4h", "2w" or "90m",
+// or "5m 30s" as stored in spent. Days and weeks are working days (8h) and weeks (5d); a plain number is hours.
+func ParseEstimate(s string) (time.Duration, error) {
+	s = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), " ", "")
+	if s == "" {
+		return 0, fmt.Errorf("invalid estimate: empty")
+	}
+
+	if hours, err := strconv.ParseFloat(s, 64); err == nil {
+		if hours < 0 {
+			return 0, fmt.Errorf("invalid estimate %q: must not be negative", s)
+		}
+		return time.Duration(hours * float64(time.Hour)), nil
+	}
+
+	units := map[string]time.Duration{"w": WorkWeek, "d": WorkDay, "h": time.Hour, "m": time.Minute, "s": time.Second}
+	var total time.Duration
+	for rest := s; rest != ""; {
+		m := estimatePart.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("invalid estimate %q: expected a duration like 30m, 4h, 1.5d or 2w", s)
+		}
+		n, _ := strconv.ParseFloat(m[1], 64)
+		total += time.Duration(n * float64(units[m[2]]))
+		rest = rest[len(m[0]):]
+	}
+	return total, nil
+}
+
+// FormatEstimate renders an amount of work in working weeks, days, hours and
+// minutes, e.g. "1w 2d 4h". Zero is "0h".
+func FormatEstimate(d time.Duration) string {
+	if d <= 0 {
+		return "0h"
+	}
+
+	var parts []string
+	for _, u := range []struct {
+		unit string
+		size time.Duration
+	}{{"w", WorkWeek}, {"d", WorkDay}, {"h", time.Hour}, {"m", time.Minute}} {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.unit))
+			d -= n * u.size
+		}
+	}
+	if len(parts) == 0 {
+		return "0h"
+	}
+	return strings.Join(parts, " ")
+}
+
+// EstimateDuration returns the ticket's parsed estimate, or 0 when it has none
+// or it can't be parsed.
+func (t *Ticket) EstimateDuration() time.Duration {
+	if t.Estimate == "" {
+		return 0
+	}
+	d, err := ParseEstimate(t.Estimate)
+	if err != nil {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,56 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"4h", 4 * time.Hour},
+		{"90m", 90 * time.Minute},
+		{"1d", 8 * time.Hour},
+		{"1.5d", 12 * time.Hour},
+		{"2w", 80 * time.Hour},
+		{"1d 4h", 12 * time.Hour},
+		{"3", 3 * time.Hour},
+		{"0.5", 30 * time.Minute},
+		{"1h 5m 30s", time.Hour + 5*time.Minute + 30*time.Second},
+	}
+	for _, tt := range tests {
+		got, err := ParseEstimate(tt.input)
+		if err != nil {
+			t.Errorf("ParseEstimate(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseEstimate(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "3x", "h", "-2", "4h!"} {
+		if _, err := ParseEstimate(input); err == nil {
+			t.Errorf("ParseEstimate(%q) should fail", input)
+		}
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{0, "0h"},
+		{30 * time.Minute, "30m"},
+		{12 * time.Hour, "1d 4h"},
+		{WorkWeek + 2*WorkDay + 90*time.Minute, "1w 2d 1h 30m"},
+	}
+	for _, tt := range tests {
+		if got := FormatEstimate(tt.input); got != tt.want {
+			t.Errorf("FormatEstimate(%v) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package tickets
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// csvColumns is the header of a CSV export.
+var csvColumns = []string{
+	"id", "title", "status", "type", "priority", "assignee", "parent", "deps", "links", "tags",
+	"external_ref", "created", "updated", "started_at", "closed_at", "description",
+}
+
+// ExportCSV writes the tickets as CSV with a header row. List fields are joined
+// with commas and an empty status is written as "open". Text that a spreadsheet
+// would run as a formula is prefixed with a quote.
+func ExportCSV(w io.Writer, items []*Ticket) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, t := range items {
+		row := []string{
+			t.ID, t.Title, displayStatus(t.Status), t.Type, strconv.Itoa(t.Priority), t.Assignee, t.Parent,
+			strings.Join(t.Deps, ","), strings.Join(t.Links, ","), strings.Join(t.Tags, ","),
+			t.ExternalRef, t.Created, t.Updated, t.StartedAt, t.ClosedAt, t.Description,
+		}
+		for i, cell := range row {
+			if csvColumns[i] != "priority" {
+				row[i] = csvCell(cell)
+			}
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell escapes a value that starts like a spreadsheet formula (=, +, - or @)
+// by prefixing it with a quote, so opening the export doesn't run it.
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// exportRef is a link to a related ticket. Href is empty when the ticket is not
+// part of the export.
+type exportRef struct {
+	ID     string
+	Title  string
+	Status string
+	Href   template.URL
+}
+
+// exportTicket is a ticket with its relations resolved to links.
+type exportTicket struct {
+	*Ticket
+	Status   string
+	Href     template.URL
+	Parent   *exportRef
+	Deps     []exportRef
+	Blocking []exportRef
+	Children []exportRef
+	Linked   []exportRef
+	Blocked  bool
+}
+
+// exportGroup is the tickets of one status, in index order.
+type exportGroup struct {
+	Status  string
+	Tickets []*exportTicket
+}
+
+// exportPage is the data of an exported HTML page.
+type exportPage struct {
+	Title     string
+	Generated string
+	Total     int
+	Groups    []exportGroup
+	Ticket    *exportTicket // set on a ticket page of a site
+	IndexHref template.URL
+}
+
+// buildExport resolves the relations of the exported tickets and groups them by
+// status, in the configured status order, sorted by priority then ID. href maps
+// an exported ticket ID to its link target.
+func buildExport(items, allTickets []*Ticket, cfg *config.Config, href func(id string) string) []exportGroup {
+	exported := make(map[string]bool)
+	for _, t := range items {
+		exported[t.ID] = true
+	}
+	byID := make(map[string]*Ticket)
+	for _, t := range allTickets {
+		byID[t.ID] = t
+	}
+
+	ref := func(t *Ticket) exportRef {
+		r := exportRef{ID: t.ID, Title: t.Title, Status: displayStatus(t.Status)}
+		if exported[t.ID] {
+			r.Href = template.URL(href(t.ID))
+		}
+		return r
+	}
+	refs := func(items []*Ticket) []exportRef {
+		var result []exportRef
+		for _, t := range items {
+			result = append(result, ref(t))
+		}
+		return result
+	}
+
+	sorted := append([]*Ticket(nil), items...)
+	SortTickets(sorted, []SortKey{{Field: "priority"}})
+
+	groups := make(map[string]*exportGroup)
+	var order []string
+	for _, s := range cfg.Statuses {
+		order = append(order, displayStatus(s))
+	}
+	for _, t := range sorted {
+		rel := ComputeRelations(t, allTickets, cfg)
+		et := &exportTicket{
+			Ticket:   t,
+			Status:   displayStatus(t.Status),
+			Href:     template.URL(href(t.ID)),
+			Blocking: refs(rel.Blocking),
+			Children: refs(rel.Children),
+			Linked:   refs(rel.Linked),
+			Blocked:  len(rel.Blockers) > 0,
+		}
+		if rel.ParentTicket != nil {
+			p := ref(rel.ParentTicket)
+			et.Parent = &p
+		}
+		for _, id := range t.Deps {
+			if dep, ok := byID[id]; ok {
+				et.Deps = append(et.Deps, ref(dep))
+			}
+		}
+
+		g, ok := groups[et.Status]
+		if !ok {
+			g = &exportGroup{Status: et.Status}
+			groups[et.Status] = g
+			if !containsString(order, et.Status) {
+				order = append(order, et.Status)
+			}
+		}
+		g.Tickets = append(g.Tickets, et)
+	}
+
+	var result []exportGroup
+	for _, s := range order {
+		if g, ok := groups[s]; ok {
+			result = append(result, *g)
+		}
+	}
+	return result
+}
+
+// ExportHTML writes a single self-contained HTML report: an index of the tickets
+// grouped by status, followed by every ticket with its relations as in-page links.
+func ExportHTML(w io.Writer, title string, items, allTickets []*Ticket, cfg *config.Config) error {
+	page := exportPage{
+		Title:     title,
+		Generated: time.Now().UTC().Format("2006-01-02 15:04 UTC"),
+		Total:     len(items),
+		Groups:    buildExport(items, allTickets, cfg, func(id string) string { return "#" + id }),
+	}
+	return exportTemplates.ExecuteTemplate(w, "report", page)
+}
+
+// ExportSite writes a static site to outDir: index.html with the tickets grouped
+// by status, and one <ID>.html page per ticket linking to its parent, children,
+// deps, blocked tickets and links. Existing files with the same names are replaced.
+func ExportSite(outDir string, title string, items, allTickets []*Ticket, cfg *config.Config) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+
+	page := exportPage{
+		Title:     title,
+		Generated: time.Now().UTC().Format("2006-01-02 15:04 UTC"),
+		Total:     len(items),
+		Groups:    buildExport(items, allTickets, cfg, func(id string) string { return id + ".html" }),
+		IndexHref: "index.html",
+	}
+
+	write := func(name, tmpl string, data exportPage) error {
+		var b strings.Builder
+		if err := exportTemplates.ExecuteTemplate(&b, tmpl, data); err != nil {
+			return err
+		}
+		return atomicWriteFile(filepath.Join(outDir, name), []byte(b.String()))
+	}
+
+	if err := write("index.html", "index", page); err != nil {
+		return err
+	}
+	for _, g := range page.Groups {
+		for _, t := range g.Tickets {
+			p := page
+			p.Ticket = t
+			if err := write(t.ID+".html", "ticket", p); err != nil {
+				return fmt.Errorf("writing %s: %w", t.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// exportCSS is inlined in every exported page so they work without other files.
+const exportCSS = `body{font-family:system-ui,sans-serif;max-width:60rem;margin:2rem auto;padding:0 1rem;color:#222}
+a{color:#0550ae;text-decoration:none}a:hover{text-decoration:underline}
+h2{border-bottom:1px solid #ddd;padding-bottom:.3rem;margin-top:2rem}
+table{border-collapse:collapse;width:100%}td,th{text-align:left;padding:.25rem .5rem;border-bottom:1px solid #eee;vertical-align:top}
+.id{font-family:monospace;color:#8250df}.muted{color:#777}.tag{background:#eef;border-radius:3px;padding:0 .3rem;margin-right:.2rem;font-size:.85em}
+.status{font-size:.85em;border-radius:3px;padding:0 .3rem;background:#eee}.blocked{color:#cf222e}
+pre{white-space:pre-wrap;font-family:inherit;background:#f6f8fa;padding:.75rem;border-radius:4px}
+section.ticket{border-top:1px solid #ddd;margin-top:1.5rem}dl{display:grid;grid-template-columns:max-content auto;gap:.2rem 1rem}dt{color:#777}dd{margin:0}`
+
+var exportTemplates = template.Must(template.New("export").Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>` + exportCSS + `</style>
+</head>
+<body>
+{{end}}
+
+{{define "ref"}}{{if .Href}}<a href="{{.Href}}"><span class="id">{{.ID}}</span> {{.Title}}</a>{{else}}<span class="id">{{.ID}}</span> {{.Title}}{{end}} <span class="status">{{.Status}}</span>{{end}}
+
+{{define "refs"}}{{range $i, $r := .}}{{if $i}}<br>{{end}}{{template "ref" $r}}{{end}}{{end}}
+
+{{define "groups"}}{{range .Groups}}
+<h2>{{.Status}} <span class="muted">({{len .Tickets}})</span></h2>
+<table>
+<tr><th>ID</th><th>P</th><th>Type</th><th>Title</th><th>Assignee</th><th>Tags</th></tr>
+{{range .Tickets}}<tr><td><a class="id" href="{{.Href}}">{{.ID}}</a></td><td>P{{.Priority}}</td><td>{{.Type}}</td><td><a href="{{.Href}}">{{.Title}}</a>{{if .Blocked}} <span class="blocked">blocked</span>{{end}}</td><td>{{.Assignee}}</td><td>{{range .Tags}}<span class="tag">{{.}}</span>{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+
+{{define "details"}}<dl>
+<dt>Status</dt><dd><span class="status">{{.Status}}</span>{{if .Blocked}} <span class="blocked">blocked</span>{{end}}</dd>
+{{if .Type}}<dt>Type</dt><dd>{{.Type}}</dd>{{end}}
+<dt>Priority</dt><dd>P{{.Priority}}</dd>
+{{if .Assignee}}<dt>Assignee</dt><dd>{{.Assignee}}</dd>{{end}}
+{{if .Tags}}<dt>Tags</dt><dd>{{range .Tags}}<span class="tag">{{.}}</span>{{end}}</dd>{{end}}
+{{if .ExternalRef}}<dt>External ref</dt><dd>{{.ExternalRef}}</dd>{{end}}
+{{if .Created}}<dt>Created</dt><dd>{{.Created}}</dd>{{end}}
+{{if .Updated}}<dt>Updated</dt><dd>{{.Updated}}</dd>{{end}}
+{{if .ClosedAt}}<dt>Closed</dt><dd>{{.ClosedAt}}</dd>{{end}}
+{{with .Parent}}<dt>Parent</dt><dd>{{template "ref" .}}</dd>{{end}}
+{{if .Children}}<dt>Children</dt><dd>{{template "refs" .Children}}</dd>{{end}}
+{{if .Deps}}<dt>Depends on</dt><dd>{{template "refs" .Deps}}</dd>{{end}}
+{{if .Blocking}}<dt>Blocking</dt><dd>{{template "refs" .Blocking}}</dd>{{end}}
+{{if .Linked}}<dt>Links</dt><dd>{{template "refs" .Linked}}</dd>{{end}}
+</dl>
+{{if .Description}}<pre>{{.Description}}</pre>{{end}}
+{{if .Design}}<h3>Design</h3><pre>{{.Design}}</pre>{{end}}
+{{if .Acceptance}}<h3>Acceptance</h3><pre>{{.Acceptance}}</pre>{{end}}
+{{end}}
+
+{{define "report"}}{{template "head" .Title}}<h1>{{.Title}}</h1>
+<p class="muted">{{.Total}} tickets, generated {{.Generated}}</p>
+{{template "groups" .}}
+{{range .Groups}}{{range .Tickets}}<section class="ticket" id="{{.ID}}">
+<h2><span class="id">{{.ID}}</span> {{.Title}}</h2>
+{{template "details" .}}</section>
+{{end}}{{end}}</body>
+</html>
+{{end}}
+
+{{define "index"}}{{template "head" .Title}}<h1>{{.Title}}</h1>
+<p class="muted">{{.Total}} tickets, generated {{.Generated}}</p>
+{{template "groups" .}}</body>
+</html>
+{{end}}
+
+{{define "ticket"}}{{with .Ticket}}{{template "head" .Title}}{{end}}<p><a href="{{.IndexHref}}">&larr; {{.Title}}</a></p>
+{{with .Ticket}}<h1><span class="id">{{.ID}}</span> {{.Title}}</h1>
+{{template "details" .}}{{end}}</body>
+</html>
+{{end}}
+`))
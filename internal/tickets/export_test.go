@@ -0,0 +1,130 @@
+package tickets
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+func exportFixture() []*Ticket {
+	return []*Ticket{
+		{ID: "aaa", Title: "Epic <one>", Type: "epic", Status: "closed", Priority: 2},
+		{ID: "bbb", Title: "Child", Parent: "aaa", Deps: []string{"ccc"}, Tags: []string{"ui", "perf"}, Priority: 1},
+		{ID: "ccc", Title: "Dep", Description: "line 1\nline 2", Status: "in_progress", Priority: 2},
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := ExportCSV(&b, exportFixture()); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(rows) != 4 || rows[0][0] != "id" {
+		t.Fatalf("got %d rows, header %v", len(rows), rows[0])
+	}
+	if got := strings.Join(rows[2][:10], "|"); got != "bbb|Child|open||1||aaa|ccc||ui,perf" {
+		t.Errorf("row = %q", got)
+	}
+	if rows[3][len(rows[3])-1] != "line 1\nline 2" {
+		t.Errorf("description = %q", rows[3][len(rows[3])-1])
+	}
+}
+
+func TestBuildExportGroupsByStatus(t *testing.T) {
+	items := exportFixture()
+	groups := buildExport(items, items, config.Default(), func(id string) string { return id + ".html" })
+
+	var order []string
+	for _, g := range groups {
+		order = append(order, g.Status)
+	}
+	if got := strings.Join(order, ","); got != "open,in_progress,closed" {
+		t.Errorf("group order = %q", got)
+	}
+
+	child := groups[0].Tickets[0]
+	if child.ID != "bbb" || child.Parent == nil || child.Parent.Href != "aaa.html" || !child.Blocked {
+		t.Errorf("child = %+v", child)
+	}
+	if len(child.Deps) != 1 || child.Deps[0].Href != "ccc.html" {
+		t.Errorf("deps = %+v", child.Deps)
+	}
+
+	// Tickets outside the export are shown without a link
+	groups = buildExport(items[1:], items, config.Default(), func(id string) string { return id + ".html" })
+	if p := groups[0].Tickets[0].Parent; p == nil || p.Href != "" {
+		t.Errorf("parent outside the export should not be linked: %+v", p)
+	}
+}
+
+func TestExportHTML(t *testing.T) {
+	items := exportFixture()
+	var b bytes.Buffer
+	if err := ExportHTML(&b, "Backlog", items, items, config.Default()); err != nil {
+		t.Fatalf("ExportHTML: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{"<title>Backlog</title>", `id="bbb"`, `href="#ccc"`, "Epic &lt;one&gt;", "<style>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML report should contain %q", want)
+		}
+	}
+	if strings.Contains(out, "Epic <one>") {
+		t.Error("titles should be escaped")
+	}
+}
+
+func TestExportSite(t *testing.T) {
+	items := exportFixture()
+	dir := filepath.Join(tempDir(t), "site")
+	if err := ExportSite(dir, "Backlog", items, items, config.Default()); err != nil {
+		t.Fatalf("ExportSite: %v", err)
+	}
+
+	for _, name := range []string{"index.html", "aaa.html", "bbb.html", "ccc.html"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s: %v", name, err)
+		}
+	}
+
+	page, _ := os.ReadFile(filepath.Join(dir, "aaa.html"))
+	for _, want := range []string{`href="index.html"`, `href="bbb.html"`, "Children"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("aaa.html should contain %q", want)
+		}
+	}
+	index, _ := os.ReadFile(filepath.Join(dir, "index.html"))
+	if !strings.Contains(string(index), "in_progress") || !strings.Contains(string(index), `href="ccc.html"`) {
+		t.Errorf("index should group by status and link tickets:\n%s", index)
+	}
+}
+
+func TestExportCSVEscapesFormulas(t *testing.T) {
+	var b bytes.Buffer
+	items := []*Ticket{{ID: "aaa", Title: "=HYPERLINK(\"http://x\")", Assignee: "@bob", Tags: []string{"+1"}, Description: "-2 days", Priority: 1}}
+	if err := ExportCSV(&b, items); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	row := rows[1]
+	if row[1] != `'=HYPERLINK("http://x")` || row[5] != "'@bob" || row[9] != "'+1" || row[15] != "'-2 days" {
+		t.Errorf("formula cells not escaped: %q", row)
+	}
+	if row[0] != "aaa" || row[4] != "1" {
+		t.Errorf("plain cells changed: %q", row)
+	}
+}
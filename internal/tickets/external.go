@@ -0,0 +1,348 @@
+package tickets
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// githubFields are the fields every GitHub issue sets on existing tickets. The
+// type is only set when a label names one.
+var githubFields = []string{"title", "status", "assignee", "tags", "description"}
+
+// jiraFields are the fields every Jira issue sets on existing tickets. The type
+// and priority are only set when they map to configured values.
+var jiraFields = []string{"title", "status", "assignee", "tags", "description"}
+
+// githubIssue is an issue as exported by `gh issue list --json ...`.
+type githubIssue struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	State     string `json:"state"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+	ClosedAt  string `json:"closedAt"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+}
+
+// githubIssueURL extracts "owner/repo" and the number from an issue URL.
+var githubIssueURL = regexp.MustCompile(`github\.com/([^/]+/[^/]+)/issues/(\d+)`)
+
+// ParseGitHubIssues reads a JSON array of issues as produced by
+// `gh issue list --json number,title,body,state,labels,assignees,url,createdAt,closedAt`.
+// The external ref is "owner/repo#number" (or "#number" without a URL), labels become
+// tags, a label naming a configured type sets the type, the first assignee becomes the
+// assignee and closed issues get the done status. Issues without a type label
+// leave the type out of their record, so re-importing them keeps the local type.
+func ParseGitHubIssues(data []byte, cfg *config.Config) ([]ImportRecord, error) {
+	var issues []githubIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("invalid GitHub issues JSON: %w", err)
+	}
+
+	var result []ImportRecord
+	for _, issue := range issues {
+		r := ImportRecord{Fields: append([]string(nil), githubFields...)}
+		t := &Ticket{
+			Title:       issue.Title,
+			Description: strings.TrimSpace(strings.ReplaceAll(issue.Body, "\r\n", "\n")),
+			Status:      "open",
+			Created:     normalizeTimestamp(issue.CreatedAt),
+			ExternalRef: fmt.Sprintf("#%d", issue.Number),
+		}
+		if m := githubIssueURL.FindStringSubmatch(issue.URL); m != nil {
+			t.ExternalRef = m[1] + "#" + m[2]
+		}
+		if strings.EqualFold(issue.State, "closed") {
+			t.Status = cfg.DoneStatus()
+			t.ClosedAt = normalizeTimestamp(issue.ClosedAt)
+		}
+		for _, label := range issue.Labels {
+			t.Tags = append(t.Tags, label.Name)
+			if lower := strings.ToLower(label.Name); cfg.ValidType(lower) {
+				if t.Type == "" {
+					r.Fields = append(r.Fields, "type")
+				}
+				t.Type = lower
+			}
+		}
+		if len(issue.Assignees) > 0 {
+			t.Assignee = issue.Assignees[0].Login
+		}
+		r.Ticket = t
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// jiraIssue holds the fields read from a Jira export, before mapping.
+type jiraIssue struct {
+	Key         string
+	Summary     string
+	Type        string
+	Status      string
+	Priority    string
+	Assignee    string
+	Created     string
+	Description string
+	Labels      []string
+}
+
+// ParseJira reads a Jira CSV or XML (RSS) export, detected from its content.
+// The issue key becomes the external ref; type, status and priority names are
+// mapped to the configured values (see jiraRecord).
+func ParseJira(data []byte, cfg *config.Config) ([]ImportRecord, error) {
+	var issues []jiraIssue
+	var err error
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		issues, err = parseJiraXML(data)
+	} else {
+		issues, err = parseJiraCSV(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ImportRecord
+	for _, issue := range issues {
+		result = append(result, jiraRecord(issue, cfg))
+	}
+	return result, nil
+}
+
+// parseJiraCSV reads a Jira CSV export. Columns are found by header name; Jira
+// repeats the "Labels" column once per label.
+func parseJiraCSV(data []byte) ([]jiraIssue, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Jira CSV: %w", err)
+	}
+	columns := make(map[string][]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = append(columns[name], i)
+	}
+	if columns["issue key"] == nil || columns["summary"] == nil {
+		return nil, fmt.Errorf("invalid Jira CSV: missing Issue key or Summary column")
+	}
+
+	var issues []jiraIssue
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid Jira CSV: %w", err)
+		}
+
+		get := func(name string) string {
+			for _, i := range columns[name] {
+				if i < len(row) {
+					return strings.TrimSpace(row[i])
+				}
+			}
+			return ""
+		}
+
+		issue := jiraIssue{
+			Key:         get("issue key"),
+			Summary:     get("summary"),
+			Type:        get("issue type"),
+			Status:      get("status"),
+			Priority:    get("priority"),
+			Assignee:    get("assignee"),
+			Created:     get("created"),
+			Description: get("description"),
+		}
+		for _, i := range columns["labels"] {
+			if i < len(row) && strings.TrimSpace(row[i]) != "" {
+				issue.Labels = append(issue.Labels, strings.TrimSpace(row[i]))
+			}
+		}
+		if issue.Key == "" {
+			return nil, fmt.Errorf("invalid Jira CSV: line %d: missing issue key", line)
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// jiraRSS is the structure of a Jira XML export.
+type jiraRSS struct {
+	Items []struct {
+		Key         string   `xml:"key"`
+		Summary     string   `xml:"summary"`
+		Type        string   `xml:"type"`
+		Status      string   `xml:"status"`
+		Priority    string   `xml:"priority"`
+		Assignee    string   `xml:"assignee"`
+		Created     string   `xml:"created"`
+		Description string   `xml:"description"`
+		Labels      []string `xml:"labels>label"`
+	} `xml:"channel>item"`
+}
+
+// htmlTag matches HTML tags in Jira XML descriptions.
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// parseJiraXML reads a Jira XML (RSS) export. Descriptions are HTML and are
+// reduced to plain text.
+func parseJiraXML(data []byte) ([]jiraIssue, error) {
+	var rss jiraRSS
+	if err := xml.Unmarshal(data, &rss); err != nil {
+		return nil, fmt.Errorf("invalid Jira XML: %w", err)
+	}
+
+	var issues []jiraIssue
+	for _, item := range rss.Items {
+		description := strings.ReplaceAll(item.Description, "<br/>", "\n")
+		description = strings.ReplaceAll(description, "</p>", "\n\n")
+		description = html.UnescapeString(htmlTag.ReplaceAllString(description, ""))
+
+		issue := jiraIssue{
+			Key:         strings.TrimSpace(item.Key),
+			Summary:     strings.TrimSpace(item.Summary),
+			Type:        strings.TrimSpace(item.Type),
+			Status:      strings.TrimSpace(item.Status),
+			Priority:    strings.TrimSpace(item.Priority),
+			Assignee:    strings.TrimSpace(item.Assignee),
+			Created:     strings.TrimSpace(item.Created),
+			Description: strings.TrimSpace(description),
+			Labels:      item.Labels,
+		}
+		if issue.Key == "" {
+			return nil, fmt.Errorf("invalid Jira XML: item without a key")
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// jiraTypes maps Jira issue types to ticket types, when the config allows them.
+var jiraTypes = map[string]string{
+	"bug":      "bug",
+	"story":    "feature",
+	"feature":  "feature",
+	"task":     "task",
+	"sub-task": "task",
+	"subtask":  "task",
+	"epic":     "epic",
+}
+
+// jiraPriorities maps Jira priority names to the default 0-4 range.
+var jiraPriorities = map[string]int{
+	"blocker":  0,
+	"highest":  0,
+	"critical": 1,
+	"high":     1,
+	"major":    2,
+	"medium":   2,
+	"minor":    3,
+	"low":      3,
+	"trivial":  4,
+	"lowest":   4,
+}
+
+// jiraDoneStatuses are the Jira status names mapped to the done status.
+var jiraDoneStatuses = []string{"done", "closed", "resolved"}
+
+// jiraRecord maps a Jira issue to an import record. A type or priority that the
+// config doesn't allow is left out of the record, so new tickets get the configured
+// default and existing ones keep theirs; a status it doesn't allow falls back to "open".
+func jiraRecord(issue jiraIssue, cfg *config.Config) ImportRecord {
+	r := ImportRecord{Fields: append([]string(nil), jiraFields...)}
+	t := &Ticket{
+		Title:       issue.Summary,
+		Description: issue.Description,
+		Assignee:    issue.Assignee,
+		ExternalRef: issue.Key,
+		Tags:        issue.Labels,
+		Status:      "open",
+		Created:     parseJiraDate(issue.Created),
+	}
+	if strings.EqualFold(t.Assignee, "unassigned") {
+		t.Assignee = ""
+	}
+
+	issueType := strings.ToLower(issue.Type)
+	if mapped, ok := jiraTypes[issueType]; ok && cfg.ValidType(mapped) {
+		t.Type = mapped
+	} else if cfg.ValidType(issueType) {
+		t.Type = issueType
+	}
+	if t.Type != "" {
+		r.Fields = append(r.Fields, "type")
+	}
+
+	if p, ok := jiraPriorities[strings.ToLower(issue.Priority)]; ok && cfg.ValidPriority(p) {
+		t.Priority = p
+		r.Fields = append(r.Fields, "priority")
+	}
+
+	status := strings.ReplaceAll(strings.ToLower(issue.Status), " ", "_")
+	switch {
+	case containsString(jiraDoneStatuses, status):
+		t.Status = cfg.DoneStatus()
+	case status == "in_progress" || status == "in_review":
+		t.Status = "in_progress"
+	case cfg.ValidStatus(status):
+		t.Status = status
+	}
+	if !cfg.ValidStatus(t.Status) {
+		t.Status = "open"
+	}
+
+	r.Ticket = t
+	return r
+}
+
+// jiraDateFormats are the date formats found in Jira CSV and XML exports.
+var jiraDateFormats = []string{
+	time.RFC3339,
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"02/Jan/06 3:04 PM",
+	"2006-01-02 15:04",
+}
+
+// parseJiraDate converts a Jira date to RFC3339 in UTC, or returns "" if it
+// can't be parsed.
+func parseJiraDate(s string) string {
+	for _, layout := range jiraDateFormats {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// normalizeTimestamp converts an RFC3339 time to UTC, or returns "" if it can't be parsed.
+func normalizeTimestamp(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
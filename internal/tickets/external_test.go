@@ -0,0 +1,281 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+func TestParseGitHubIssues(t *testing.T) {
+	data := []byte(`[
+		{"number": 12, "title": "Crash on save", "body": "Steps\r\nhere", "state": "OPEN",
+		 "url": "https://github.com/acme/app/issues/12", "createdAt": "2026-01-02T10:00:00+01:00",
+		 "labels": [{"name": "Bug"}, {"name": "ui"}], "assignees": [{"login": "alice"}, {"login": "bob"}]},
+		{"number": 13, "title": "Docs", "state": "CLOSED", "closedAt": "2026-01-04T10:00:00Z"}
+	]`)
+
+	records, err := ParseGitHubIssues(data, config.Default())
+	if err != nil {
+		t.Fatalf("ParseGitHubIssues: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	r := records[0]
+	if r.ExternalRef != "acme/app#12" || r.Type != "bug" || !r.has("type") || r.Assignee != "alice" || r.Status != "open" {
+		t.Errorf("record 0 = %+v", r)
+	}
+	if strings.Join(r.Tags, ",") != "Bug,ui" || r.Description != "Steps\nhere" {
+		t.Errorf("tags = %v, description = %q", r.Tags, r.Description)
+	}
+	if r.Created != "2026-01-02T09:00:00Z" {
+		t.Errorf("created = %q", r.Created)
+	}
+
+	r = records[1]
+	if r.ExternalRef != "#13" || r.Status != "closed" || r.ClosedAt != "2026-01-04T10:00:00Z" {
+		t.Errorf("record 1 = %+v", r)
+	}
+	if r.has("type") || r.has("priority") {
+		t.Errorf("an issue without a type label should leave type and priority out, got fields %v", r.Fields)
+	}
+
+	if _, err := ParseGitHubIssues([]byte(`{"number": 1}`), config.Default()); err == nil {
+		t.Error("expected an error for a non-array input")
+	}
+}
+
+func TestParseJiraCSV(t *testing.T) {
+	data := []byte("Summary,Issue key,Issue Type,Status,Priority,Assignee,Created,Labels,Labels\n" +
+		"Login fails,PROJ-1,Bug,In Progress,Highest,bob,12/Mar/26 3:04 PM,auth,backend\n" +
+		"Add export,PROJ-2,Story,Resolved,Trivial,Unassigned,,,\n" +
+		"Odd,PROJ-3,Incident,Waiting,Whatever,,,,\n")
+
+	records, err := ParseJira(data, config.Default())
+	if err != nil {
+		t.Fatalf("ParseJira: %v", err)
+	}
+
+	tests := []struct {
+		ref, typ, status, assignee, tags, created string
+		priority                                  int
+	}{
+		{"PROJ-1", "bug", "in_progress", "bob", "auth,backend", "2026-03-12T15:04:00Z", 0},
+		{"PROJ-2", "feature", "closed", "", "", "", 4},
+		{"PROJ-3", "", "open", "", "", "", 0},
+	}
+	if len(records) != len(tests) {
+		t.Fatalf("got %d records, want %d", len(records), len(tests))
+	}
+	for i, tt := range tests {
+		r := records[i]
+		if r.ExternalRef != tt.ref || r.Type != tt.typ || r.Status != tt.status || r.Assignee != tt.assignee ||
+			strings.Join(r.Tags, ",") != tt.tags || r.Created != tt.created || r.Priority != tt.priority {
+			t.Errorf("record %d = %+v", i, r)
+		}
+		if r.has("type") != (tt.typ != "") || r.has("priority") != (i < 2) {
+			t.Errorf("record %d fields = %v", i, r.Fields)
+		}
+	}
+
+	if _, err := ParseJira([]byte("Title,Key\nx,y\n"), config.Default()); err == nil {
+		t.Error("expected an error for missing columns")
+	}
+}
+
+func TestParseJiraXML(t *testing.T) {
+	data := []byte(`<rss version="0.92"><channel><item>
+		<key>PROJ-7</key><summary>Slow search</summary><type>Task</type><status>Done</status>
+		<priority>Minor</priority><assignee>carol</assignee>
+		<created>Thu, 12 Mar 2026 15:04:00 +0000</created>
+		<description>&lt;p&gt;Takes &amp;gt; 5s&lt;br/&gt;on big repos&lt;/p&gt;</description>
+		<labels><label>perf</label></labels>
+	</item></channel></rss>`)
+
+	records, err := ParseJira(data, config.Default())
+	if err != nil {
+		t.Fatalf("ParseJira: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.ExternalRef != "PROJ-7" || r.Title != "Slow search" || r.Status != "closed" || r.Priority != 3 || r.Assignee != "carol" {
+		t.Errorf("record = %+v", r)
+	}
+	if r.Description != "Takes > 5s\non big repos" {
+		t.Errorf("description = %q", r.Description)
+	}
+	if r.Created != "2026-03-12T15:04:00Z" || strings.Join(r.Tags, ",") != "perf" {
+		t.Errorf("created = %q, tags = %v", r.Created, r.Tags)
+	}
+}
+
+func TestImportByExternalRef(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Crash", Type: "bug", Priority: 1, ExternalRef: "PROJ-1", Deps: []string{"bbb"}, Design: "Keep"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Local", Priority: 2})
+
+	opts := ImportOptions{ByExternalRef: true}
+	jiraRecords := func(items []*Ticket) []ImportRecord {
+		records := asRecords(items)
+		for i := range records {
+			records[i].Fields = append(jiraFields, "type", "priority")
+		}
+		return records
+	}
+	actions, err := Import(dir, jiraRecords([]*Ticket{
+		{Title: "Crash on save", Type: "bug", Priority: 1, ExternalRef: "PROJ-1"},
+		{Title: "New", Type: "task", Priority: 2, ExternalRef: "PROJ-2"},
+	}), opts)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if actions[0].Created || actions[0].Ticket.ID != "aaa" || len(actions[0].Changes) != 1 {
+		t.Errorf("PROJ-1 should update aaa's title only: %+v", actions[0])
+	}
+	updated, _ := Show(dir, "aaa")
+	if updated.Title != "Crash on save" || len(updated.Deps) != 1 || updated.Design != "Keep" {
+		t.Errorf("fields outside the import should be kept: %+v", updated)
+	}
+	if !actions[1].Created || actions[1].Ticket.ID == "" {
+		t.Errorf("PROJ-2 should be created: %+v", actions[1])
+	}
+
+	// Running the same import again changes nothing
+	actions, err = Import(dir, jiraRecords([]*Ticket{
+		{Title: "Crash on save", Type: "bug", Priority: 1, ExternalRef: "PROJ-1"},
+		{Title: "New", Type: "task", Priority: 2, ExternalRef: "PROJ-2"},
+	}), opts)
+	if err != nil {
+		t.Fatalf("second Import: %v", err)
+	}
+	for _, a := range actions {
+		if a.Created || len(a.Changes) > 0 {
+			t.Errorf("second import should change nothing: %+v", a)
+		}
+	}
+
+	_, err = Import(dir, jiraRecords([]*Ticket{
+		{Title: "A", Type: "task", ExternalRef: "PROJ-9"},
+		{Title: "B", Type: "task", ExternalRef: "PROJ-9"},
+	}), opts)
+	if err == nil || !strings.Contains(err.Error(), "duplicate external_ref PROJ-9") {
+		t.Errorf("expected duplicate external_ref error, got %v", err)
+	}
+}
+
+func TestImportByExternalRefKeepsLocalState(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Crash", Type: "bug", Status: "in_progress", ExternalRef: "acme/app#1", Tags: []string{"local"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Old", Type: "task", Status: "in_progress", ExternalRef: "acme/app#2"})
+
+	records := []ImportRecord{
+		{Ticket: &Ticket{Title: "Crash", Type: "bug", Status: "open", ExternalRef: "acme/app#1", Tags: []string{"ui"}}, Fields: append(githubFields, "type")},
+		{Ticket: &Ticket{Title: "Old", Type: "task", Status: "closed", ExternalRef: "acme/app#2"}, Fields: append(githubFields, "type")},
+	}
+	if _, err := Import(dir, records, ImportOptions{ByExternalRef: true}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	open, _ := Show(dir, "aaa")
+	if open.Status != "in_progress" {
+		t.Errorf("open issue should keep the local status, got %q", open.Status)
+	}
+	if got := strings.Join(open.Tags, ","); got != "local,ui" {
+		t.Errorf("tags = %q, want labels added to the local tags", got)
+	}
+
+	closed, _ := Show(dir, "bbb")
+	if closed.Status != "closed" {
+		t.Errorf("closed issue should close the ticket, got %q", closed.Status)
+	}
+}
+
+func TestReimportKeepsLocalType(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	github := []byte(`[{"number": 1, "title": "Docs", "state": "OPEN", "url": "https://github.com/acme/app/issues/1"}]`)
+	jira := []byte("Summary,Issue key,Issue Type,Priority\nOdd,PROJ-3,Incident,Whatever\n")
+
+	for _, tt := range []struct {
+		name  string
+		parse func([]byte, *config.Config) ([]ImportRecord, error)
+		data  []byte
+	}{
+		{"github", ParseGitHubIssues, github},
+		{"jira", ParseJira, jira},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			importOnce := func() *Ticket {
+				records, err := tt.parse(tt.data, config.Default())
+				if err != nil {
+					t.Fatalf("parse: %v", err)
+				}
+				actions, err := Import(dir, records, ImportOptions{ByExternalRef: true})
+				if err != nil {
+					t.Fatalf("Import: %v", err)
+				}
+				return actions[0].Ticket
+			}
+
+			created := importOnce()
+			if created.Type != "task" || created.Priority != 2 {
+				t.Fatalf("new ticket should get the default type and priority, got %q %d", created.Type, created.Priority)
+			}
+
+			if _, _, err := Set(dir, []string{created.ID}, mustParseChanges(t, "type=chore", "priority=0")); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			importOnce()
+
+			got, _ := Show(dir, created.ID)
+			if got.Type != "chore" || got.Priority != 0 {
+				t.Errorf("re-import should keep the local type and priority, got %q %d", got.Type, got.Priority)
+			}
+		})
+	}
+}
+
+func TestImportByExternalRefSkipsArchived(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Crash", Type: "bug", Status: "closed", ExternalRef: "PROJ-1"})
+	if _, err := Archive(dir, []string{"aaa"}); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+
+	records := []ImportRecord{
+		{Ticket: &Ticket{Title: "Crash on save", Status: "closed", ExternalRef: "PROJ-1"}, Fields: jiraFields},
+		{Ticket: &Ticket{Title: "New", Status: "open", ExternalRef: "PROJ-2"}, Fields: jiraFields},
+	}
+	actions, err := Import(dir, records, ImportOptions{ByExternalRef: true})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if len(actions) != 2 || !actions[0].Archived || actions[0].Ticket.ID != "aaa" || actions[0].Created {
+		t.Fatalf("PROJ-1 should be skipped as archived: %+v", actions)
+	}
+	if !actions[1].Created {
+		t.Errorf("PROJ-2 should be created: %+v", actions[1])
+	}
+
+	all, _ := List(dir)
+	if len(all) != 1 || all[0].ExternalRef != "PROJ-2" {
+		t.Errorf("only PROJ-2 should be imported, got %d active tickets", len(all))
+	}
+	archived, _ := Show(dir, "aaa")
+	if archived.Title != "Crash" {
+		t.Errorf("archived ticket should be left alone, got title %q", archived.Title)
+	}
+}
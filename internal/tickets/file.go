@@ -1,6 +1,7 @@
 package tickets
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,14 +9,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juanibiapina/todo/internal/config"
 	"gopkg.in/yaml.v3"
 )
 
-const defaultDirName = "docs/tickets"
-
-// DirPath returns the path to the tickets directory in the given directory.
-func DirPath(dir string) string {
-	return filepath.Join(dir, defaultDirName)
+// DirPath returns the path to the tickets directory in the given directory,
+// as configured by the repository config file.
+func DirPath(dir string) (string, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cfg.TicketsDir), nil
 }
 
 // ticketFileName generates the filename for a ticket.
@@ -24,16 +29,38 @@ func ticketFileName(id string) string {
 }
 
 // ticketFilePath returns the full path for a ticket file.
-func ticketFilePath(dir, id string) string {
-	return filepath.Join(DirPath(dir), ticketFileName(id))
+func ticketFilePath(dir, id string) (string, error) {
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(ticketsDir, ticketFileName(id)), nil
 }
 
-// findTicketFile finds a ticket file by ID.
+// fileID returns the ticket ID derived from a ticket file's name.
+func fileID(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), ".md")
+}
+
+// errTicketNotFound is returned (wrapped with the ID) when no ticket file matches an ID.
+var errTicketNotFound = errors.New("ticket not found")
+
+// findTicketFile finds a ticket file by ID in the tickets directory.
 // It tries an exact match first, then falls back to partial (substring) matching.
 // Returns an error if zero or multiple tickets match a partial ID.
 func findTicketFile(dir, id string) (string, error) {
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return "", err
+	}
+	return findTicketFileIn(ticketsDir, id)
+}
+
+// findTicketFileIn finds a ticket file by ID in the given directory.
+// See findTicketFile.
+func findTicketFileIn(ticketsDir, id string) (string, error) {
 	// Try exact match first
-	path := ticketFilePath(dir, id)
+	path := filepath.Join(ticketsDir, ticketFileName(id))
 	if _, err := os.Stat(path); err == nil {
 		return path, nil
 	} else if !os.IsNotExist(err) {
@@ -41,11 +68,10 @@ func findTicketFile(dir, id string) (string, error) {
 	}
 
 	// Fall back to partial matching: scan all .md files
-	ticketsDir := DirPath(dir)
 	entries, err := os.ReadDir(ticketsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", fmt.Errorf("ticket not found: %s", id)
+			return "", fmt.Errorf("%w: %s", errTicketNotFound, id)
 		}
 		return "", err
 	}
@@ -63,7 +89,7 @@ func findTicketFile(dir, id string) (string, error) {
 
 	switch len(matches) {
 	case 0:
-		return "", fmt.Errorf("ticket not found: %s", id)
+		return "", fmt.Errorf("%w: %s", errTicketNotFound, id)
 	case 1:
 		return matches[0], nil
 	default:
@@ -131,27 +157,113 @@ func parseFile(path string) (*Ticket, error) {
 		Priority:    fm.Priority,
 		Assignee:    fm.Assignee,
 		Created:     fm.Created,
+		Updated:     fm.Updated,
+		StartedAt:   fm.StartedAt,
+		ClosedAt:    fm.ClosedAt,
 		Parent:      fm.Parent,
 		ExternalRef: fm.ExternalRef,
 		Design:      fm.Design,
 		Acceptance:  fm.Acceptance,
+		Estimate:    fm.Estimate,
+		Spent:       fm.Spent,
+		Due:         fm.Due,
+		Scheduled:   fm.Scheduled,
+		Recur:       fm.Recur,
 		Deps:        fm.Deps,
 		Links:       fm.Links,
 		Tags:        fm.Tags,
+		History:     fm.History,
+		Sessions:    fm.Sessions,
 	}
 
 	return ticket, nil
 }
 
+// currentTimestamp returns the current time in the format used for frontmatter timestamps.
+func currentTimestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// setStatus changes a ticket's status, stamps the matching timestamps and records
+// the change in the ticket's history. It does nothing if the status is unchanged.
+// closed_at is set when the ticket becomes done and cleared when it leaves a done status.
+// started_at is set whenever work starts on the ticket: when it moves from open or a
+// done status to any other status, in_progress or a configured one like "doing".
+func setStatus(actor string, t *Ticket, status string, cfg *config.Config) {
+	if displayStatus(status) == displayStatus(t.Status) {
+		return
+	}
+
+	// An empty status means open
+	old := t.Status
+	if old == "" {
+		old = "open"
+	}
+	recordChange(actor, t, "status", old, status)
+
+	now := currentTimestamp()
+	if cfg.IsDone(status) {
+		t.ClosedAt = now
+	} else {
+		t.ClosedAt = ""
+	}
+	if status != "open" && !cfg.IsDone(status) && (old == "open" || cfg.IsDone(old)) {
+		t.StartedAt = now
+	}
+	t.Status = status
+}
+
 // writeFile writes a ticket to its file.
 func writeFile(dir string, t *Ticket) error {
-	path := ticketFilePath(dir, t.ID)
-	return os.WriteFile(path, []byte(t.FullString()), 0644)
+	path, err := ticketFilePath(dir, t.ID)
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(path, []byte(t.FullString()))
+}
+
+// atomicWriteFile writes data to a temporary file in the same directory and
+// renames it over path, so readers never see a partially written ticket.
+// The temporary file name doesn't end in .md, so List never picks it up.
+func atomicWriteFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
 }
 
 // EnsureDir creates the tickets directory if it doesn't exist.
 func EnsureDir(dir string) error {
-	ticketsDir := DirPath(dir)
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return err
+	}
 	return os.MkdirAll(ticketsDir, 0755)
 }
 
@@ -166,19 +278,73 @@ func existingIDs(tickets []*Ticket) map[string]bool {
 	return ids
 }
 
+// ParseWarning reports a ticket file that couldn't be read cleanly.
+type ParseWarning struct {
+	Path string // path to the ticket file
+	ID   string // ticket ID derived from the filename
+	Err  error
+}
+
+func (w ParseWarning) Error() string {
+	return fmt.Sprintf("%s: %v", w.Path, w.Err)
+}
+
+// errMissingTitle is reported for tickets without a "# Title" line after the frontmatter.
+var errMissingTitle = errors.New("missing title line")
+
 // List returns all tickets from the tickets directory.
+// Files that can't be parsed are skipped; use ListWithWarnings to see them.
 func List(dir string) ([]*Ticket, error) {
-	ticketsDir := DirPath(dir)
+	tickets, _, err := ListWithWarnings(dir)
+	return tickets, err
+}
+
+// ListWithWarnings returns all tickets from the tickets directory, plus a warning
+// for each file that couldn't be parsed (left out of the list) or is missing its
+// title line (still listed).
+func ListWithWarnings(dir string) ([]*Ticket, []ParseWarning, error) {
+	entries, warnings, err := listEntries(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tickets []*Ticket
+	for _, e := range entries {
+		tickets = append(tickets, e.ticket)
+	}
+
+	return tickets, warnings, nil
+}
+
+// ticketEntry is a parsed ticket together with the file it was read from.
+type ticketEntry struct {
+	id     string // ID derived from the filename
+	path   string
+	ticket *Ticket
+}
+
+// listEntries parses every ticket file in the tickets directory, sorted by filename.
+func listEntries(dir string) ([]ticketEntry, []ParseWarning, error) {
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return listEntriesIn(ticketsDir)
+}
 
+// listEntriesIn parses every ticket file in the given directory, sorted by filename.
+// Subdirectories (such as the archive) are not included.
+func listEntriesIn(ticketsDir string) ([]ticketEntry, []ParseWarning, error) {
 	entries, err := os.ReadDir(ticketsDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
-	var tickets []*Ticket
+	var parsed []ticketEntry
+	var warnings []ParseWarning
 	var filenames []string
 
 	// Collect filenames for sorting
@@ -198,16 +364,19 @@ func List(dir string) ([]*Ticket, error) {
 	// Parse each file
 	for _, filename := range filenames {
 		path := filepath.Join(ticketsDir, filename)
+		id := strings.TrimSuffix(filename, ".md")
 		t, err := parseFile(path)
 		if err != nil {
-			continue // Skip files that can't be parsed
+			warnings = append(warnings, ParseWarning{Path: path, ID: id, Err: err})
+			continue
 		}
-		if t != nil {
-			tickets = append(tickets, t)
+		if t.Title == "" {
+			warnings = append(warnings, ParseWarning{Path: path, ID: id, Err: errMissingTitle})
 		}
+		parsed = append(parsed, ticketEntry{id: id, path: path, ticket: t})
 	}
 
-	return tickets, nil
+	return parsed, warnings, nil
 }
 
 // Add creates a new ticket and returns it.
@@ -217,6 +386,13 @@ func Add(dir string, t *Ticket) (*Ticket, error) {
 		return nil, err
 	}
 
+	// Hold the lock so concurrent adds can't pick the same ID
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	// Validate parent exists if set
 	if t.Parent != "" {
 		if _, err := findTicketFile(dir, t.Parent); err != nil {
@@ -224,13 +400,14 @@ func Add(dir string, t *Ticket) (*Ticket, error) {
 		}
 	}
 
-	// Get existing IDs to avoid collision
-	tickets, err := List(dir)
+	t.ID, err = newTicketID(dir)
 	if err != nil {
 		return nil, err
 	}
 
-	t.ID = generateUniqueID(existingIDs(tickets))
+	if t.Created == "" {
+		t.Created = currentTimestamp()
+	}
 
 	if err := writeFile(dir, t); err != nil {
 		return nil, err
@@ -239,9 +416,49 @@ func Add(dir string, t *Ticket) (*Ticket, error) {
 	return t, nil
 }
 
+// newTicketID returns an unused ticket ID. The caller must hold the lock.
+func newTicketID(dir string) (string, error) {
+	// Get existing IDs to avoid collision, including files that don't parse
+	tickets, warnings, err := ListWithWarnings(dir)
+	if err != nil {
+		return "", err
+	}
+	ids := existingIDs(tickets)
+	for _, w := range warnings {
+		ids[w.ID] = true
+	}
+	archived, err := archivedIDs(dir)
+	if err != nil {
+		return "", err
+	}
+	for id := range archived {
+		ids[id] = true
+	}
+
+	return generateUniqueID(ids), nil
+}
+
 // Show returns a ticket by ID.
+// Archived tickets are found if no ticket in the tickets directory matches.
 func Show(dir string, id string) (*Ticket, error) {
+	path, err := findShowableFile(dir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFile(path)
+}
+
+// Get returns the active ticket with the given ID (partial IDs allowed). Unlike
+// Show it doesn't fall back to the archive, since archived tickets can't be
+// changed: an ID that only matches an archived ticket is reported as archived.
+func Get(dir string, id string) (*Ticket, error) {
 	path, err := findTicketFile(dir, id)
+	if errors.Is(err, errTicketNotFound) {
+		if archivedPath, archiveErr := findShowableFile(dir, id); archiveErr == nil {
+			return nil, fmt.Errorf("ticket %s is archived", fileID(archivedPath))
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -249,62 +466,149 @@ func Show(dir string, id string) (*Ticket, error) {
 	return parseFile(path)
 }
 
-// Done marks a ticket as closed by setting its status.
-func Done(dir string, id string) (string, error) {
+// FilePath returns the path to a ticket's file, resolving partial IDs
+// and falling back to the archive like Show.
+func FilePath(dir string, id string) (string, error) {
+	return findShowableFile(dir, id)
+}
+
+// findShowableFile finds a ticket file by ID, falling back to the archive.
+func findShowableFile(dir, id string) (string, error) {
 	path, err := findTicketFile(dir, id)
+	if errors.Is(err, errTicketNotFound) {
+		archiveDir, dirErr := ArchiveDirPath(dir)
+		if dirErr != nil {
+			return "", dirErr
+		}
+		if archivedPath, archiveErr := findTicketFileIn(archiveDir, id); archiveErr == nil {
+			return archivedPath, nil
+		}
+	}
+	return path, err
+}
+
+// Done marks a ticket as done by setting its status to the configured done status.
+// When the ticket recurs, Done also creates its next occurrence, links the two
+// and returns it; otherwise the returned ticket is nil.
+func Done(dir string, id string) (string, *Ticket, error) {
+	cfg, err := config.Load(dir)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	path, err := findTicketFile(dir, id)
+	if err != nil {
+		return "", nil, err
 	}
 
 	t, err := parseFile(path)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	if err := cfg.CheckTransition(t.Status, cfg.DoneStatus()); err != nil {
+		return "", nil, err
 	}
 
-	t.Status = "closed"
+	oldStatus := t.Status
+	setStatus(actor, t, cfg.DoneStatus(), cfg)
 
-	if err := writeFile(dir, t); err != nil {
-		return "", err
+	next, err := closeAndWrite(dir, actor, t, oldStatus, cfg)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return t.Title, nil
+	return t.Title, next, nil
 }
 
-// validStatuses lists the allowed ticket statuses.
-var validStatuses = map[string]bool{
-	"open":        true,
-	"in_progress": true,
-	"closed":      true,
+// closeAndWrite writes a ticket whose status just changed from oldStatus and,
+// when that closed a recurring ticket, creates and writes its next occurrence.
+func closeAndWrite(dir, actor string, t *Ticket, oldStatus string, cfg *config.Config) (*Ticket, error) {
+	next, err := occurrenceOnClose(t, oldStatus, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		if err := linkOccurrence(dir, actor, t, next); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeFile(dir, t); err != nil {
+		return nil, err
+	}
+	if next != nil {
+		if err := writeFile(dir, next); err != nil {
+			return nil, err
+		}
+	}
+	return next, nil
 }
 
-// SetStatus changes a ticket's status after validation.
-func SetStatus(dir string, id string, status string) (string, error) {
-	if !validStatuses[status] {
-		return "", fmt.Errorf("invalid status: %q (valid: open, in_progress, closed)", status)
+// SetStatus changes a ticket's status after validating it against the configured
+// statuses and transitions. Like Done, closing a recurring ticket creates its
+// next occurrence, which is returned; otherwise the returned ticket is nil.
+func SetStatus(dir string, id string, status string) (string, *Ticket, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := cfg.CheckStatus(status); err != nil {
+		return "", nil, err
+	}
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", nil, err
 	}
+	defer unlock()
+
+	actor := gitActor(dir)
 
 	path, err := findTicketFile(dir, id)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	t, err := parseFile(path)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	t.Status = status
+	if err := cfg.CheckTransition(t.Status, status); err != nil {
+		return "", nil, err
+	}
 
-	if err := writeFile(dir, t); err != nil {
-		return "", err
+	oldStatus := t.Status
+	setStatus(actor, t, status, cfg)
+
+	next, err := closeAndWrite(dir, actor, t, oldStatus, cfg)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return t.Title, nil
+	return t.Title, next, nil
 }
 
 // AddDep adds a dependency from one ticket to another.
 // Both tickets must exist. The operation is idempotent.
 func AddDep(dir string, id string, depID string) error {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
 	// Resolve and validate the ticket
 	path, err := findTicketFile(dir, id)
 	if err != nil {
@@ -333,6 +637,7 @@ func AddDep(dir string, id string, depID string) error {
 	}
 
 	t.Deps = append(t.Deps, resolvedDepID)
+	recordChange(actor, t, "deps", "", resolvedDepID)
 
 	return writeFile(dir, t)
 }
@@ -340,6 +645,14 @@ func AddDep(dir string, id string, depID string) error {
 // RemoveDep removes a dependency from a ticket.
 // Both tickets must exist. The operation is idempotent.
 func RemoveDep(dir string, id string, depID string) error {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
 	// Resolve and validate the ticket
 	path, err := findTicketFile(dir, id)
 	if err != nil {
@@ -367,6 +680,9 @@ func RemoveDep(dir string, id string, depID string) error {
 			newDeps = append(newDeps, d)
 		}
 	}
+	if len(newDeps) != len(t.Deps) {
+		recordChange(actor, t, "deps", resolvedDepID, "")
+	}
 	t.Deps = newDeps
 
 	return writeFile(dir, t)
@@ -376,6 +692,14 @@ func RemoveDep(dir string, id string, depID string) error {
 // All tickets must exist. The operation is idempotent.
 // For 3+ IDs, all pairs are linked.
 func AddLink(dir string, ids []string) error {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
 	// Resolve all IDs and load tickets
 	type resolved struct {
 		id   string
@@ -414,6 +738,7 @@ func AddLink(dir string, ids []string) error {
 			}
 			if !found {
 				t.Links = append(t.Links, other.id)
+				recordChange(actor, t, "links", "", other.id)
 				modified = true
 			}
 		}
@@ -431,6 +756,14 @@ func AddLink(dir string, ids []string) error {
 // RemoveLink removes a bidirectional link between two tickets.
 // Both tickets must exist. The operation is idempotent.
 func RemoveLink(dir string, id string, targetID string) error {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
 	// Resolve both IDs
 	path, err := findTicketFile(dir, id)
 	if err != nil {
@@ -455,6 +788,9 @@ func RemoveLink(dir string, id string, targetID string) error {
 			newLinks = append(newLinks, l)
 		}
 	}
+	if len(newLinks) != len(t.Links) {
+		recordChange(actor, t, "links", resolvedTargetID, "")
+	}
 	t.Links = newLinks
 	if err := writeFile(dir, t); err != nil {
 		return err
@@ -471,6 +807,9 @@ func RemoveLink(dir string, id string, targetID string) error {
 			newLinks2 = append(newLinks2, l)
 		}
 	}
+	if len(newLinks2) != len(t2.Links) {
+		recordChange(actor, t2, "links", resolvedID, "")
+	}
 	t2.Links = newLinks2
 	if err := writeFile(dir, t2); err != nil {
 		return err
@@ -479,8 +818,26 @@ func RemoveLink(dir string, id string, targetID string) error {
 	return nil
 }
 
+// splitNotes splits a description into the text before its "## Notes" section
+// and the notes after the heading.
+func splitNotes(description string) (string, string) {
+	i := strings.Index(description, "## Notes")
+	if i < 0 {
+		return description, ""
+	}
+	return strings.TrimRight(description[:i], "\n"), description[i+len("## Notes"):]
+}
+
 // AddNote appends a timestamped note to a ticket's description under a ## Notes section.
 func AddNote(dir string, id string, text string) (string, error) {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
 	path, err := findTicketFile(dir, id)
 	if err != nil {
 		return "", err
@@ -505,8 +862,9 @@ func AddNote(dir string, id string, text string) (string, error) {
 			t.Description = "## Notes\n\n" + noteEntry
 		}
 	}
+	recordChange(actor, t, "notes", "", "")
 
-	if err := os.WriteFile(path, []byte(t.FullString()), 0644); err != nil {
+	if err := atomicWriteFile(path, []byte(t.FullString())); err != nil {
 		return "", err
 	}
 
@@ -515,6 +873,14 @@ func AddNote(dir string, id string, text string) (string, error) {
 
 // SetDescription sets or replaces a ticket's description.
 func SetDescription(dir string, id string, description string) (string, error) {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
 	path, err := findTicketFile(dir, id)
 	if err != nil {
 		return "", err
@@ -525,12 +891,97 @@ func SetDescription(dir string, id string, description string) (string, error) {
 		return "", err
 	}
 
+	if t.Description != description {
+		recordChange(actor, t, "description", "", "")
+	}
 	t.Description = description
 
 	// Overwrite in place — filename doesn't depend on title
-	if err := os.WriteFile(path, []byte(t.FullString()), 0644); err != nil {
+	if err := atomicWriteFile(path, []byte(t.FullString())); err != nil {
 		return "", err
 	}
 
 	return t.Title, nil
 }
+
+// Remove deletes a ticket and strips its ID from the deps and links of every
+// other ticket, archived ones included. Children of the removed ticket are
+// moved to its parent if reparent is true, and left without a parent otherwise.
+// Returns the removed ticket and the IDs of the tickets that were updated.
+func Remove(dir string, id string, reparent bool) (*Ticket, []string, error) {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	path, err := findShowableFile(dir, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	removed, err := parseFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	removedID := fileID(path)
+
+	newParent := ""
+	if reparent {
+		newParent = removed.Parent
+	}
+
+	active, _, err := listEntries(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	archiveDir, err := ArchiveDirPath(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	archived, _, err := listEntriesIn(archiveDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updated []string
+	for _, e := range append(active, archived...) {
+		if e.path == path {
+			continue
+		}
+
+		t := e.ticket
+		changed := false
+
+		if containsString(t.Deps, removedID) {
+			t.Deps = removeString(t.Deps, removedID)
+			recordChange(actor, t, "deps", removedID, "")
+			changed = true
+		}
+		if containsString(t.Links, removedID) {
+			t.Links = removeString(t.Links, removedID)
+			recordChange(actor, t, "links", removedID, "")
+			changed = true
+		}
+		if t.Parent == removedID {
+			recordChange(actor, t, "parent", removedID, newParent)
+			t.Parent = newParent
+			changed = true
+		}
+
+		if changed {
+			if err := atomicWriteFile(e.path, []byte(t.FullString())); err != nil {
+				return nil, nil, err
+			}
+			updated = append(updated, e.id)
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		return nil, nil, err
+	}
+
+	return removed, updated, nil
+}
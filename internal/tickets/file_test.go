@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func tempDir(t *testing.T) string {
@@ -13,6 +14,24 @@ func tempDir(t *testing.T) string {
 	return dir
 }
 
+func mustDirPath(t *testing.T, dir string) string {
+	t.Helper()
+	path, err := DirPath(dir)
+	if err != nil {
+		t.Fatalf("DirPath: %v", err)
+	}
+	return path
+}
+
+func mustArchiveDirPath(t *testing.T, dir string) string {
+	t.Helper()
+	path, err := ArchiveDirPath(dir)
+	if err != nil {
+		t.Fatalf("ArchiveDirPath: %v", err)
+	}
+	return path
+}
+
 func TestAddAndList(t *testing.T) {
 	dir := tempDir(t)
 
@@ -106,7 +125,7 @@ func TestDone(t *testing.T) {
 		t.Fatalf("Add: %v", err)
 	}
 
-	title, err := Done(dir, ticket.ID)
+	title, _, err := Done(dir, ticket.ID)
 	if err != nil {
 		t.Fatalf("Done: %v", err)
 	}
@@ -115,7 +134,7 @@ func TestDone(t *testing.T) {
 	}
 
 	// File should still exist
-	path := filepath.Join(DirPath(dir), ticket.ID+".md")
+	path := filepath.Join(mustDirPath(t, dir), ticket.ID+".md")
 	if _, err := os.Stat(path); err != nil {
 		t.Errorf("file should still exist: %v", err)
 	}
@@ -237,7 +256,7 @@ func TestNotFoundErrors(t *testing.T) {
 		t.Error("Show: expected error")
 	}
 
-	_, err = Done(dir, "nonexistent")
+	_, _, err = Done(dir, "nonexistent")
 	if err == nil {
 		t.Error("Done: expected error")
 	}
@@ -260,6 +279,72 @@ func TestListEmptyDir(t *testing.T) {
 	}
 }
 
+func TestListWithWarnings(t *testing.T) {
+	dir := tempDir(t)
+
+	good, err := Add(dir, &Ticket{Title: "Good"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	files := map[string]string{
+		"nod.md": "id: nod\n# No delimiter\n",
+		"unc.md": "---\nid: unc\n# Unclosed\n",
+		"yml.md": "---\nid: [yml\n---\n# Bad YAML\n",
+		"not.md": "---\nid: not\n---\nNo title here\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(mustDirPath(t, dir), name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	tickets, warnings, err := ListWithWarnings(dir)
+	if err != nil {
+		t.Fatalf("ListWithWarnings: %v", err)
+	}
+
+	// The ticket without a title is still listed
+	if len(tickets) != 2 {
+		t.Fatalf("expected good and untitled tickets, got %d tickets", len(tickets))
+	}
+	if tickets[0].ID != good.ID && tickets[1].ID != good.ID {
+		t.Errorf("expected %s to be listed", good.ID)
+	}
+
+	want := map[string]string{
+		"nod": "missing frontmatter opening delimiter",
+		"unc": "missing frontmatter closing delimiter",
+		"yml": "invalid frontmatter YAML",
+		"not": "missing title line",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("expected %d warnings, got %d: %v", len(want), len(warnings), warnings)
+	}
+	for _, w := range warnings {
+		msg, ok := want[w.ID]
+		if !ok {
+			t.Errorf("unexpected warning for %q: %v", w.ID, w.Err)
+			continue
+		}
+		if !strings.Contains(w.Err.Error(), msg) {
+			t.Errorf("warning for %s = %q, want it to contain %q", w.ID, w.Err, msg)
+		}
+		if filepath.Base(w.Path) != w.ID+".md" {
+			t.Errorf("warning path = %q, want file %s.md", w.Path, w.ID)
+		}
+	}
+
+	// List keeps skipping unparseable files
+	listed, err := List(dir)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("List len = %d, want 2", len(listed))
+	}
+}
+
 func TestFileFormat(t *testing.T) {
 	dir := tempDir(t)
 
@@ -270,7 +355,7 @@ func TestFileFormat(t *testing.T) {
 
 	// Check file exists with <id>.md naming
 	expectedFilename := ticket.ID + ".md"
-	path := filepath.Join(DirPath(dir), expectedFilename)
+	path := filepath.Join(mustDirPath(t, dir), expectedFilename)
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -322,7 +407,7 @@ func TestTicketFileName(t *testing.T) {
 func TestEnsureDir(t *testing.T) {
 	dir := tempDir(t)
 
-	ticketsDir := DirPath(dir)
+	ticketsDir := mustDirPath(t, dir)
 
 	// Directory shouldn't exist yet
 	if _, err := os.Stat(ticketsDir); !os.IsNotExist(err) {
@@ -473,7 +558,7 @@ func TestSetStatus(t *testing.T) {
 	}
 
 	// Set to in_progress
-	title, err := SetStatus(dir, ticket.ID, "in_progress")
+	title, _, err := SetStatus(dir, ticket.ID, "in_progress")
 	if err != nil {
 		t.Fatalf("SetStatus: %v", err)
 	}
@@ -490,7 +575,7 @@ func TestSetStatus(t *testing.T) {
 	}
 
 	// Set to closed
-	_, err = SetStatus(dir, ticket.ID, "closed")
+	_, _, err = SetStatus(dir, ticket.ID, "closed")
 	if err != nil {
 		t.Fatalf("SetStatus closed: %v", err)
 	}
@@ -503,7 +588,7 @@ func TestSetStatus(t *testing.T) {
 	}
 
 	// Set to open
-	_, err = SetStatus(dir, ticket.ID, "open")
+	_, _, err = SetStatus(dir, ticket.ID, "open")
 	if err != nil {
 		t.Fatalf("SetStatus open: %v", err)
 	}
@@ -524,7 +609,7 @@ func TestSetStatusInvalid(t *testing.T) {
 		t.Fatalf("Add: %v", err)
 	}
 
-	_, err = SetStatus(dir, ticket.ID, "invalid")
+	_, _, err = SetStatus(dir, ticket.ID, "invalid")
 	if err == nil {
 		t.Error("SetStatus with invalid status should fail")
 	}
@@ -537,7 +622,7 @@ func TestSetStatusNotFound(t *testing.T) {
 	dir := tempDir(t)
 	EnsureDir(dir)
 
-	_, err := SetStatus(dir, "zzz", "open")
+	_, _, err := SetStatus(dir, "zzz", "open")
 	if err == nil {
 		t.Error("SetStatus with non-existent ID should fail")
 	}
@@ -546,6 +631,258 @@ func TestSetStatusNotFound(t *testing.T) {
 	}
 }
 
+func TestSetStatusConfiguredStatuses(t *testing.T) {
+	dir := tempDir(t)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("statuses: [open, review, closed]\n"), 0644)
+
+	ticket, err := Add(dir, &Ticket{Title: "Custom status test"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, _, err := SetStatus(dir, ticket.ID, "review"); err != nil {
+		t.Fatalf("SetStatus review: %v", err)
+	}
+	loaded, err := Show(dir, ticket.ID)
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if loaded.Status != "review" {
+		t.Errorf("status = %q, want %q", loaded.Status, "review")
+	}
+
+	_, _, err = SetStatus(dir, ticket.ID, "in_progress")
+	if err == nil || !strings.Contains(err.Error(), "invalid status") {
+		t.Errorf("expected invalid status error, got %v", err)
+	}
+}
+
+func TestSetStatusEnforcesTransitions(t *testing.T) {
+	dir := tempDir(t)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte(`transitions:
+  open: [in_progress, closed]
+  in_progress: [open, closed]
+  closed: [open]
+`), 0644)
+
+	ticket, err := Add(dir, &Ticket{Title: "Workflow test"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, _, err := Done(dir, ticket.ID); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+
+	_, _, err = SetStatus(dir, ticket.ID, "in_progress")
+	if err == nil {
+		t.Fatal("closed -> in_progress should be rejected")
+	}
+	if !strings.Contains(err.Error(), "allowed: open") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Status is unchanged after a rejected transition
+	loaded, err := Show(dir, ticket.ID)
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if loaded.Status != "closed" {
+		t.Errorf("status = %q, want %q", loaded.Status, "closed")
+	}
+
+	if _, _, err := SetStatus(dir, ticket.ID, "open"); err != nil {
+		t.Fatalf("SetStatus open: %v", err)
+	}
+	if _, _, err := SetStatus(dir, ticket.ID, "in_progress"); err != nil {
+		t.Fatalf("SetStatus in_progress: %v", err)
+	}
+}
+
+func TestDoneUsesConfiguredDoneStatus(t *testing.T) {
+	dir := tempDir(t)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("statuses: [open, done]\ndone_statuses: [done]\n"), 0644)
+
+	ticket, err := Add(dir, &Ticket{Title: "Finish me"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, _, err := Done(dir, ticket.ID); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+
+	loaded, err := Show(dir, ticket.ID)
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if loaded.Status != "done" {
+		t.Errorf("status = %q, want %q", loaded.Status, "done")
+	}
+}
+
+func TestConfiguredTicketsDir(t *testing.T) {
+	dir := tempDir(t)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("tickets_dir: tickets\n"), 0644)
+
+	ticket, err := Add(dir, &Ticket{Title: "Elsewhere"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	path := filepath.Join(dir, "tickets", ticket.ID+".md")
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected ticket at %s: %v", path, err)
+	}
+}
+
+func TestInvalidConfigFailsInsteadOfUsingDefaultDir(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	writeFile(dir, &Ticket{ID: "aaa", Title: "In the default dir"})
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("tickets_dir: \"\"\n"), 0644)
+
+	if _, err := DirPath(dir); err == nil || !strings.Contains(err.Error(), "invalid config") {
+		t.Errorf("DirPath error = %v, want the config error", err)
+	}
+	if _, err := Show(dir, "aaa"); err == nil || !strings.Contains(err.Error(), "invalid config") {
+		t.Errorf("Show error = %v, want the config error", err)
+	}
+}
+
+func TestAddStampsCreated(t *testing.T) {
+	dir := tempDir(t)
+
+	ticket, err := Add(dir, &Ticket{Title: "Stamped"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	loaded, err := Show(dir, ticket.ID)
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if _, err := time.Parse(time.RFC3339, loaded.Created); err != nil {
+		t.Errorf("created = %q is not RFC 3339: %v", loaded.Created, err)
+	}
+
+	// An explicit created value is preserved
+	ticket, err = Add(dir, &Ticket{Title: "Imported", Created: "2025-01-01T00:00:00Z"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if ticket.Created != "2025-01-01T00:00:00Z" {
+		t.Errorf("created = %q, want preserved value", ticket.Created)
+	}
+}
+
+func TestSetStatusStampsTimestamps(t *testing.T) {
+	dir := tempDir(t)
+
+	ticket, err := Add(dir, &Ticket{Title: "Lifecycle"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, _, err := SetStatus(dir, ticket.ID, "in_progress"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	loaded, _ := Show(dir, ticket.ID)
+	if loaded.StartedAt == "" {
+		t.Error("started_at should be set when moving to in_progress")
+	}
+	if loaded.Updated == "" {
+		t.Error("updated should be set")
+	}
+	if loaded.ClosedAt != "" {
+		t.Errorf("closed_at = %q, want empty", loaded.ClosedAt)
+	}
+
+	if _, _, err := Done(dir, ticket.ID); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+	loaded, _ = Show(dir, ticket.ID)
+	if loaded.ClosedAt == "" {
+		t.Error("closed_at should be set when closed")
+	}
+
+	if _, _, err := SetStatus(dir, ticket.ID, "open"); err != nil {
+		t.Fatalf("SetStatus open: %v", err)
+	}
+	loaded, _ = Show(dir, ticket.ID)
+	if loaded.ClosedAt != "" {
+		t.Errorf("closed_at = %q, should be cleared on reopen", loaded.ClosedAt)
+	}
+	if loaded.StartedAt == "" {
+		t.Error("started_at should be kept on reopen")
+	}
+}
+
+func TestSetStatusStampsStartedForConfiguredStatus(t *testing.T) {
+	dir := tempDir(t)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("statuses: [open, doing, review, closed]\n"), 0644)
+
+	ticket, err := Add(dir, &Ticket{Title: "Custom flow"})
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if _, _, err := SetStatus(dir, ticket.ID, "doing"); err != nil {
+		t.Fatalf("SetStatus doing: %v", err)
+	}
+	loaded, _ := Show(dir, ticket.ID)
+	if loaded.StartedAt == "" {
+		t.Fatal("started_at should be set when moving from open to a configured status")
+	}
+
+	// Moving on between statuses of the same piece of work keeps the start time
+	loaded.StartedAt = "2026-01-01T00:00:00Z"
+	writeFile(dir, loaded)
+	if _, _, err := SetStatus(dir, ticket.ID, "review"); err != nil {
+		t.Fatalf("SetStatus review: %v", err)
+	}
+	loaded, _ = Show(dir, ticket.ID)
+	if loaded.StartedAt != "2026-01-01T00:00:00Z" {
+		t.Errorf("started_at = %q, want it kept", loaded.StartedAt)
+	}
+}
+
+func TestMutationsStampUpdated(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "B"})
+
+	mutations := []struct {
+		name string
+		run  func() error
+		id   string
+	}{
+		{"AddDep", func() error { return AddDep(dir, "aaa", "bbb") }, "aaa"},
+		{"RemoveDep", func() error { return RemoveDep(dir, "aaa", "bbb") }, "aaa"},
+		{"AddLink", func() error { return AddLink(dir, []string{"aaa", "bbb"}) }, "bbb"},
+		{"RemoveLink", func() error { return RemoveLink(dir, "aaa", "bbb") }, "bbb"},
+		{"AddNote", func() error { _, err := AddNote(dir, "aaa", "note"); return err }, "aaa"},
+		{"SetDescription", func() error { _, err := SetDescription(dir, "bbb", "desc"); return err }, "bbb"},
+	}
+
+	for _, m := range mutations {
+		// Reset updated so each mutation must stamp it again
+		ticket, _ := Show(dir, m.id)
+		ticket.Updated = ""
+		writeFile(dir, ticket)
+
+		if err := m.run(); err != nil {
+			t.Fatalf("%s: %v", m.name, err)
+		}
+		loaded, _ := Show(dir, m.id)
+		if loaded.Updated == "" {
+			t.Errorf("%s: updated not stamped", m.name)
+		}
+	}
+}
+
 func TestParseFileDescriptionWithDashes(t *testing.T) {
 	dir := tempDir(t)
 	EnsureDir(dir)
@@ -1164,3 +1501,84 @@ func TestFindTicketFileNotFound(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestRemoveStripsReferences(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "rem", Title: "Remove me", Links: []string{"aaa"}})
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Depends", Deps: []string{"rem", "bbb"}, Links: []string{"rem"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Unrelated"})
+	os.MkdirAll(mustArchiveDirPath(t, dir), 0755)
+	os.WriteFile(filepath.Join(mustArchiveDirPath(t, dir), "old.md"), []byte("---\nid: old\nstatus: closed\ndeps:\n    - rem\n---\n# Old\n"), 0644)
+
+	removed, updated, err := Remove(dir, "rem", false)
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if removed.Title != "Remove me" {
+		t.Errorf("removed title = %q", removed.Title)
+	}
+	if len(updated) != 2 || updated[0] != "aaa" || updated[1] != "old" {
+		t.Errorf("updated = %v, want [aaa old]", updated)
+	}
+
+	if _, err := Show(dir, "rem"); err == nil {
+		t.Error("expected removed ticket to be gone")
+	}
+
+	a, _ := Show(dir, "aaa")
+	if len(a.Deps) != 1 || a.Deps[0] != "bbb" {
+		t.Errorf("deps = %v, want [bbb]", a.Deps)
+	}
+	if len(a.Links) != 0 {
+		t.Errorf("links = %v, want none", a.Links)
+	}
+	if len(a.History) != 2 {
+		t.Errorf("expected 2 history entries, got %d", len(a.History))
+	}
+
+	old, _ := Show(dir, "old")
+	if len(old.Deps) != 0 {
+		t.Errorf("archived deps = %v, want none", old.Deps)
+	}
+}
+
+func TestRemoveChildren(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		reparent bool
+		want     string
+	}{
+		{"orphan", false, ""},
+		{"reparent", true, "top"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := tempDir(t)
+			EnsureDir(dir)
+
+			writeFile(dir, &Ticket{ID: "top", Title: "Top"})
+			writeFile(dir, &Ticket{ID: "mid", Title: "Middle", Parent: "top"})
+			writeFile(dir, &Ticket{ID: "bot", Title: "Bottom", Parent: "mid"})
+
+			if _, _, err := Remove(dir, "mid", tc.reparent); err != nil {
+				t.Fatalf("Remove: %v", err)
+			}
+
+			child, _ := Show(dir, "bot")
+			if child.Parent != tc.want {
+				t.Errorf("parent = %q, want %q", child.Parent, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemoveNotFound(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	_, _, err := Remove(dir, "zzz", false)
+	if err == nil || !strings.Contains(err.Error(), "ticket not found") {
+		t.Errorf("expected ticket not found error, got %v", err)
+	}
+}
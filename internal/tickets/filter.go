@@ -0,0 +1,693 @@
+package tickets
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Filter is a parsed filter expression that selects tickets.
+// A nil Filter matches every ticket.
+//
+// Syntax:
+//
+//	status=open             field comparison (= or :, !=, <, <=, >, >=)
+//	tag=ui,perf             comma-separated values match any of them
+//	login                   bare words and "quoted text" match title or description
+//	has:parent              the field is set (parent, deps, links, tags, ...)
+//	dep:aBc                 depends on aBc (link:aBc, parent:aBc, id:aBc)
+//	priority<=1             numeric comparison
+//	created>=2026-01-01     date comparison (YYYY-MM-DD or RFC3339)
+//	due<=+7d                due and scheduled also take today, friday, +3d, ...
+//	a b, a AND b            both must match
+//	a OR b                  either must match
+//	NOT a, -a, !a           a must not match
+//	(a OR b) c              parentheses group
+type Filter struct {
+	root filterNode
+}
+
+// filterNode is a node of a parsed filter expression.
+type filterNode interface {
+	match(t *Ticket) bool
+	fields() []string
+}
+
+type andNode struct{ children []filterNode }
+type orNode struct{ children []filterNode }
+type notNode struct{ child filterNode }
+
+func (n andNode) match(t *Ticket) bool {
+	for _, c := range n.children {
+		if !c.match(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n orNode) match(t *Ticket) bool {
+	for _, c := range n.children {
+		if c.match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n notNode) match(t *Ticket) bool { return !n.child.match(t) }
+
+func (n andNode) fields() []string { return childFields(n.children) }
+func (n orNode) fields() []string  { return childFields(n.children) }
+func (n notNode) fields() []string { return n.child.fields() }
+
+func childFields(children []filterNode) []string {
+	var result []string
+	for _, c := range children {
+		result = append(result, c.fields()...)
+	}
+	return result
+}
+
+// Match reports whether a ticket matches the filter.
+func (f *Filter) Match(t *Ticket) bool {
+	if f == nil || f.root == nil {
+		return true
+	}
+	return f.root.match(t)
+}
+
+// HasField reports whether the filter compares the given field anywhere,
+// e.g. to decide whether `list` should still hide done tickets.
+func (f *Filter) HasField(field string) bool {
+	if f == nil || f.root == nil {
+		return false
+	}
+	for _, name := range f.root.fields() {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTickets returns the tickets matching the filter, in order.
+func FilterTickets(tickets []*Ticket, f *Filter) []*Ticket {
+	var matched []*Ticket
+	for _, t := range tickets {
+		if f.Match(t) {
+			matched = append(matched, t)
+		}
+	}
+	return matched
+}
+
+// FieldFilter returns a filter matching tickets whose field equals value,
+// as if parsed from "field=value". An empty value returns nil (match all).
+func FieldFilter(field, value string) (*Filter, error) {
+	if value == "" {
+		return nil, nil
+	}
+	n, err := newTermNode(field, "=", value)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{root: n}, nil
+}
+
+// AllOf combines filters so that all of them must match. Nil filters are skipped.
+func AllOf(filters ...*Filter) *Filter {
+	var children []filterNode
+	for _, f := range filters {
+		if f != nil && f.root != nil {
+			children = append(children, f.root)
+		}
+	}
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return &Filter{root: children[0]}
+	default:
+		return &Filter{root: andNode{children: children}}
+	}
+}
+
+// ParseFilter parses a filter expression. An empty expression returns nil (match all).
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := lexFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter: unexpected %q", p.tokens[p.pos].text)
+	}
+
+	return &Filter{root: root}, nil
+}
+
+// --- Lexer ---
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenLParen
+	tokenRParen
+)
+
+type filterToken struct {
+	kind   tokenKind
+	text   string
+	quoted bool // the word starts with a quote: always text, never a keyword or field
+}
+
+// lexFilter splits an expression into words and parentheses.
+// Double quotes group text containing spaces or parentheses.
+func lexFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenRParen, text: ")"})
+			i++
+		default:
+			var b strings.Builder
+			quoted := r == '"'
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				if runes[i] == '"' {
+					end := i + 1
+					for end < len(runes) && runes[end] != '"' {
+						end++
+					}
+					if end == len(runes) {
+						return nil, fmt.Errorf("invalid filter: unterminated quote")
+					}
+					b.WriteString(string(runes[i+1 : end]))
+					i = end + 1
+					continue
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: tokenWord, text: b.String(), quoted: quoted})
+		}
+	}
+
+	return tokens, nil
+}
+
+// --- Parser ---
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *filterParser) isKeyword(tok *filterToken, keyword string) bool {
+	return tok != nil && tok.kind == tokenWord && !tok.quoted && tok.text == keyword
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *filterParser) parseOr() (filterNode, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []filterNode{first}
+
+	for p.isKeyword(p.peek(), "OR") {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+
+	if len(children) == 1 {
+		return first, nil
+	}
+	return orNode{children: children}, nil
+}
+
+// parseAnd parses: unary (["AND"] unary)*
+func (p *filterParser) parseAnd() (filterNode, error) {
+	var children []filterNode
+
+	for {
+		tok := p.peek()
+		if tok == nil || tok.kind == tokenRParen || p.isKeyword(tok, "OR") {
+			break
+		}
+		if p.isKeyword(tok, "AND") {
+			if len(children) == 0 {
+				return nil, fmt.Errorf("invalid filter: AND without a left operand")
+			}
+			p.pos++
+			if next := p.peek(); next == nil || next.kind == tokenRParen || p.isKeyword(next, "OR") {
+				return nil, fmt.Errorf("invalid filter: AND without a right operand")
+			}
+			continue
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, n)
+	}
+
+	switch len(children) {
+	case 0:
+		if tok := p.peek(); tok != nil {
+			return nil, fmt.Errorf("invalid filter: unexpected %q", tok.text)
+		}
+		return nil, fmt.Errorf("invalid filter: expected a condition")
+	case 1:
+		return children[0], nil
+	default:
+		return andNode{children: children}, nil
+	}
+}
+
+// parseUnary parses: ("NOT" | "-" | "!") unary | "(" or ")" | term
+func (p *filterParser) parseUnary() (filterNode, error) {
+	tok := p.peek()
+	if tok == nil || tok.kind == tokenRParen {
+		return nil, fmt.Errorf("invalid filter: expected a condition")
+	}
+
+	if p.isKeyword(tok, "NOT") {
+		p.pos++
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{child: child}, nil
+	}
+
+	if tok.kind == tokenLParen {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next := p.peek(); next == nil || next.kind != tokenRParen {
+			return nil, fmt.Errorf("invalid filter: missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	}
+
+	p.pos++
+	text := tok.text
+	if !tok.quoted && len(text) > 1 && (text[0] == '-' || text[0] == '!') {
+		child, err := parseTerm(text[1:], false)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{child: child}, nil
+	}
+
+	return parseTerm(text, tok.quoted)
+}
+
+// --- Terms ---
+
+// filterOperators in the order they must be tried (longest first).
+var filterOperators = []string{"!=", "<=", ">=", "=", ":", "<", ">"}
+
+// parseTerm parses "key<op>value" or a bare word (text match).
+// Quoted words are always text.
+func parseTerm(text string, quoted bool) (filterNode, error) {
+	if quoted {
+		return textNode{targets: []string{"title", "description"}, text: strings.ToLower(text)}, nil
+	}
+
+	// The key is a run of letters and underscores directly followed by an operator
+	keyEnd := 0
+	for keyEnd < len(text) && (unicode.IsLetter(rune(text[keyEnd])) || text[keyEnd] == '_') {
+		keyEnd++
+	}
+
+	if keyEnd > 0 && keyEnd < len(text) {
+		rest := text[keyEnd:]
+		for _, op := range filterOperators {
+			if strings.HasPrefix(rest, op) {
+				key := strings.ToLower(text[:keyEnd])
+				if op == ":" {
+					op = "="
+				}
+				return newTermNode(key, op, rest[len(op):])
+			}
+		}
+	}
+
+	if text != "" {
+		return textNode{targets: []string{"title", "description"}, text: strings.ToLower(text)}, nil
+	}
+	return nil, fmt.Errorf("invalid filter: empty condition")
+}
+
+// termKinds maps each filterable field to its kind.
+var termKinds = map[string]string{
+	"id":           "string",
+	"status":       "status",
+	"type":         "string",
+	"assignee":     "string",
+	"parent":       "string",
+	"external_ref": "string",
+	"tag":          "list",
+	"dep":          "list",
+	"link":         "list",
+	"priority":     "number",
+	"created":      "date",
+	"updated":      "date",
+	"started":      "date",
+	"closed":       "date",
+	"due":          "date",
+	"scheduled":    "date",
+	"title":        "text",
+	"description":  "text",
+	"text":         "text",
+	"has":          "has",
+}
+
+// filterKeyNames returns the filterable field names, for error messages.
+func filterKeyNames() string {
+	var names []string
+	for name := range termKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// hasFields lists the fields accepted by has: and how to tell they are set.
+var hasFields = []struct {
+	name string
+	set  func(t *Ticket) bool
+}{
+	{"parent", func(t *Ticket) bool { return t.Parent != "" }},
+	{"deps", func(t *Ticket) bool { return len(t.Deps) > 0 }},
+	{"links", func(t *Ticket) bool { return len(t.Links) > 0 }},
+	{"tags", func(t *Ticket) bool { return len(t.Tags) > 0 }},
+	{"assignee", func(t *Ticket) bool { return t.Assignee != "" }},
+	{"description", func(t *Ticket) bool { return t.Description != "" }},
+	{"design", func(t *Ticket) bool { return t.Design != "" }},
+	{"acceptance", func(t *Ticket) bool { return t.Acceptance != "" }},
+	{"external_ref", func(t *Ticket) bool { return t.ExternalRef != "" }},
+	{"estimate", func(t *Ticket) bool { return t.Estimate != "" }},
+	{"spent", func(t *Ticket) bool { return t.Spent != "" }},
+	{"due", func(t *Ticket) bool { return t.Due != "" }},
+	{"scheduled", func(t *Ticket) bool { return t.Scheduled != "" }},
+	{"recur", func(t *Ticket) bool { return t.Recur != "" }},
+}
+
+func newTermNode(key, op, value string) (filterNode, error) {
+	// Aliases
+	switch key {
+	case "desc":
+		key = "description"
+	case "tags":
+		key = "tag"
+	case "deps":
+		key = "dep"
+	case "links":
+		key = "link"
+	case "ref":
+		key = "external_ref"
+	}
+
+	kind, ok := termKinds[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q: must be one of %s", key, filterKeyNames())
+	}
+
+	ordered := op == "<" || op == "<=" || op == ">" || op == ">="
+	if ordered && kind != "number" && kind != "date" {
+		return nil, fmt.Errorf("invalid filter: %s does not support %s", key, op)
+	}
+
+	switch kind {
+	case "number":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %s%s%s: expected a number", key, op, value)
+		}
+		return numberNode{op: op, value: n}, nil
+
+	case "date":
+		d, err := parseFilterDate(value)
+		if err != nil && (key == "due" || key == "scheduled") {
+			if date, dateErr := ParseDate(value, time.Now()); dateErr == nil {
+				d, err = parseFilterDate(date)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %s%s%s: %w", key, op, value, err)
+		}
+		return dateNode{field: key, op: op, value: d}, nil
+
+	case "text":
+		fields := []string{key}
+		if key == "text" {
+			fields = []string{"title", "description"}
+		}
+		var n filterNode = textNode{targets: fields, text: strings.ToLower(value)}
+		if op == "!=" {
+			n = notNode{child: n}
+		}
+		return n, nil
+
+	case "has":
+		if op != "=" {
+			return nil, fmt.Errorf("invalid filter: has does not support %s", op)
+		}
+		var names []string
+		for _, f := range hasFields {
+			if f.name == value {
+				return hasNode{field: f.name, set: f.set}, nil
+			}
+			names = append(names, f.name)
+		}
+		return nil, fmt.Errorf("invalid filter: has:%s: must be one of %s", value, strings.Join(names, ", "))
+	}
+
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		values = append(values, strings.TrimSpace(v))
+	}
+
+	var n filterNode = valueNode{field: key, values: values}
+	if op == "!=" {
+		n = notNode{child: n}
+	}
+	return n, nil
+}
+
+// valueNode matches a string or list field against any of several values.
+type valueNode struct {
+	field  string
+	values []string
+}
+
+func (n valueNode) fields() []string { return []string{n.field} }
+
+func (n valueNode) match(t *Ticket) bool {
+	for _, v := range n.values {
+		if n.matchValue(t, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n valueNode) matchValue(t *Ticket, v string) bool {
+	switch n.field {
+	case "id":
+		return t.ID == v
+	case "status":
+		// "open" matches both explicit "open" and empty status (the default)
+		if v == "open" {
+			return t.Status == "" || t.Status == "open"
+		}
+		return t.Status == v
+	case "type":
+		return t.Type == v
+	case "assignee":
+		return t.Assignee == v
+	case "parent":
+		return t.Parent == v
+	case "external_ref":
+		return t.ExternalRef == v
+	case "tag":
+		return containsString(t.Tags, v)
+	case "dep":
+		return containsString(t.Deps, v)
+	case "link":
+		return containsString(t.Links, v)
+	}
+	return false
+}
+
+// numberNode compares the ticket priority.
+type numberNode struct {
+	op    string
+	value int
+}
+
+func (n numberNode) fields() []string { return []string{"priority"} }
+
+func (n numberNode) match(t *Ticket) bool {
+	return compareOp(n.op, t.Priority-n.value)
+}
+
+// compareOp applies a comparison operator to the sign of a difference.
+func compareOp(op string, diff int) bool {
+	switch op {
+	case "=":
+		return diff == 0
+	case "!=":
+		return diff != 0
+	case "<":
+		return diff < 0
+	case "<=":
+		return diff <= 0
+	case ">":
+		return diff > 0
+	case ">=":
+		return diff >= 0
+	}
+	return false
+}
+
+// filterDate is a date or instant to compare timestamps against.
+// A date without a time covers the whole UTC day.
+type filterDate struct {
+	start time.Time
+	end   time.Time // exclusive
+}
+
+func parseFilterDate(value string) (filterDate, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return filterDate{start: t, end: t.Add(time.Second)}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return filterDate{start: t, end: t.AddDate(0, 0, 1)}, nil
+	}
+	return filterDate{}, fmt.Errorf("expected a date (YYYY-MM-DD) or RFC3339 time")
+}
+
+// dateNode compares a timestamp field. Tickets without the timestamp only match !=.
+type dateNode struct {
+	field string
+	op    string
+	value filterDate
+}
+
+func (n dateNode) fields() []string { return []string{n.field} }
+
+func (n dateNode) match(t *Ticket) bool {
+	var raw string
+	switch n.field {
+	case "created":
+		raw = t.Created
+	case "updated":
+		raw = t.Updated
+	case "started":
+		raw = t.StartedAt
+	case "closed":
+		raw = t.ClosedAt
+	case "due":
+		raw = t.Due
+	case "scheduled":
+		raw = t.Scheduled
+	}
+
+	ts, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		// due and scheduled are dates, which cover the whole UTC day
+		if ts, err = time.Parse(DateLayout, raw); err != nil {
+			return n.op == "!="
+		}
+	}
+
+	inRange := !ts.Before(n.value.start) && ts.Before(n.value.end)
+	switch n.op {
+	case "=":
+		return inRange
+	case "!=":
+		return !inRange
+	case "<":
+		return ts.Before(n.value.start)
+	case "<=":
+		return ts.Before(n.value.end)
+	case ">":
+		return !ts.Before(n.value.end)
+	case ">=":
+		return !ts.Before(n.value.start)
+	}
+	return false
+}
+
+// textNode matches a case-insensitive substring of the title and/or description.
+type textNode struct {
+	targets []string
+	text    string
+}
+
+func (n textNode) fields() []string { return n.targets }
+
+func (n textNode) match(t *Ticket) bool {
+	for _, f := range n.targets {
+		var value string
+		switch f {
+		case "title":
+			value = t.Title
+		case "description":
+			value = t.Description
+		}
+		if strings.Contains(strings.ToLower(value), n.text) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNode matches tickets where a field is set.
+type hasNode struct {
+	field string
+	set   func(t *Ticket) bool
+}
+
+func (n hasNode) fields() []string { return []string{n.field} }
+
+func (n hasNode) match(t *Ticket) bool { return n.set(t) }
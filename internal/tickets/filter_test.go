@@ -0,0 +1,215 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustParseFilter(t *testing.T, expr string) *Filter {
+	t.Helper()
+	f, err := ParseFilter(expr)
+	if err != nil {
+		t.Fatalf("ParseFilter(%q): %v", expr, err)
+	}
+	return f
+}
+
+func TestParseFilterEmpty(t *testing.T) {
+	f := mustParseFilter(t, "  ")
+	if f != nil {
+		t.Errorf("expected nil filter, got %+v", f)
+	}
+	if !f.Match(&Ticket{ID: "aaa"}) {
+		t.Error("nil filter should match everything")
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"sprint=1", `unknown filter field "sprint"`},
+		{"priority=high", "expected a number"},
+		{"created>yesterday", "expected a date"},
+		{"tag<ui", "tag does not support <"},
+		{"has:nothing", "has:nothing: must be one of"},
+		{"(status=open", "missing closing parenthesis"},
+		{"status=open)", `unexpected ")"`},
+		{"status=open OR", "expected a condition"},
+		{"AND status=open", "AND without a left operand"},
+		{"status=open AND", "AND without a right operand"},
+		{"NOT", "expected a condition"},
+		{`title="unterminated`, "unterminated quote"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFilter(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseFilter(%q) error = %v, want it to contain %q", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	ticket := &Ticket{
+		ID:          "aaa",
+		Title:       "Fix login page",
+		Description: "The Sign Up button overlaps.",
+		Type:        "bug",
+		Priority:    1,
+		Assignee:    "Alice",
+		Created:     "2026-03-10T12:00:00Z",
+		Parent:      "ppp",
+		Deps:        []string{"bbb"},
+		Tags:        []string{"ui", "perf"},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		// Fields
+		{"status=open", true}, // empty status is open
+		{"status:closed", false},
+		{"status!=closed", true},
+		{"status=closed,open", true},
+		{"type=bug", true},
+		{"type!=bug", false},
+		{"assignee=Alice", true},
+		{`assignee="Bob Smith"`, false},
+		{"tag=perf", true},
+		{"tag=backend", false},
+		{"tag=ui tag=perf", true},
+		{"tag=ui tag=backend", false},
+		{"tag=backend,ui", true},
+		{"id=aaa", true},
+		{"parent:ppp", true},
+
+		// Numbers
+		{"priority<=1", true},
+		{"priority<1", false},
+		{"priority>=1", true},
+		{"priority>1", false},
+		{"priority=1", true},
+		{"priority!=1", false},
+
+		// Dates
+		{"created=2026-03-10", true},
+		{"created>=2026-03-10", true},
+		{"created>2026-03-10", false},
+		{"created<=2026-03-10", true},
+		{"created<2026-03-10", false},
+		{"created<2026-03-10T13:00:00Z", true},
+		{"created>2026-01-01", true},
+		{"closed<2030-01-01", false}, // not closed
+		{"closed!=2030-01-01", true},
+
+		// Text
+		{"login", true},
+		{"LOGIN", true},
+		{"sign", true},
+		{`"sign up"`, true},
+		{`"page sign"`, false},
+		{"title:login", true},
+		{"title:sign", false},
+		{"description:overlaps", true},
+		{"desc:login", false},
+		{"text:overlaps", true},
+		{"logout", false},
+		{`"status=open"`, false}, // quoted text is never a field
+
+		// Relations
+		{"has:parent", true},
+		{"has:deps", true},
+		{"has:links", false},
+		{"has:assignee", true},
+		{"dep:bbb", true},
+		{"dep:ccc", false},
+		{"link:bbb", false},
+
+		// Boolean operators
+		{"type=bug AND tag=ui", true},
+		{"type=task OR tag=ui", true},
+		{"type=task OR tag=backend", false},
+		{"NOT type=task", true},
+		{"NOT type=bug", false},
+		{"-type=bug", false},
+		{"!tag=backend", true},
+		{"NOT NOT type=bug", true},
+		{"(type=task OR type=bug) priority<=1", true},
+		{"type=task OR type=bug priority>1", false}, // AND binds tighter
+		{"(type=task OR type=bug) NOT (tag=ui OR tag=docs)", false},
+	}
+
+	for _, tt := range tests {
+		f := mustParseFilter(t, tt.expr)
+		if got := f.Match(ticket); got != tt.want {
+			t.Errorf("%q.Match = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestFilterHasField(t *testing.T) {
+	f := mustParseFilter(t, "tag=ui (NOT status=closed OR priority<2)")
+	for _, field := range []string{"tag", "status", "priority"} {
+		if !f.HasField(field) {
+			t.Errorf("HasField(%q) = false, want true", field)
+		}
+	}
+	if f.HasField("type") {
+		t.Error("HasField(type) = true, want false")
+	}
+
+	var none *Filter
+	if none.HasField("status") {
+		t.Error("nil filter should have no fields")
+	}
+}
+
+func TestFieldFilterAndAllOf(t *testing.T) {
+	status, err := FieldFilter("status", "in_progress")
+	if err != nil {
+		t.Fatalf("FieldFilter: %v", err)
+	}
+	empty, err := FieldFilter("tag", "")
+	if err != nil || empty != nil {
+		t.Fatalf("FieldFilter with empty value = %v, %v; want nil, nil", empty, err)
+	}
+	if _, err := FieldFilter("sprint", "12"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+
+	f := AllOf(mustParseFilter(t, "tag=ui OR tag=perf"), status, empty)
+
+	tests := []struct {
+		ticket *Ticket
+		want   bool
+	}{
+		{&Ticket{Status: "in_progress", Tags: []string{"perf"}}, true},
+		{&Ticket{Status: "in_progress"}, false},
+		{&Ticket{Tags: []string{"ui"}}, false},
+	}
+	for _, tt := range tests {
+		if got := f.Match(tt.ticket); got != tt.want {
+			t.Errorf("Match(%+v) = %v, want %v", tt.ticket, got, tt.want)
+		}
+	}
+
+	if AllOf(nil, nil) != nil {
+		t.Error("AllOf of nil filters should be nil")
+	}
+}
+
+func TestFilterTickets(t *testing.T) {
+	all := []*Ticket{
+		{ID: "aaa", Status: "in_progress"},
+		{ID: "bbb"},
+		{ID: "ccc", Status: "in_progress"},
+	}
+
+	got := FilterTickets(all, mustParseFilter(t, "status=in_progress"))
+	if len(got) != 2 || got[0].ID != "aaa" || got[1].ID != "ccc" {
+		t.Errorf("FilterTickets = %v, want [aaa ccc]", got)
+	}
+}
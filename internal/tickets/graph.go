@@ -0,0 +1,273 @@
+package tickets
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// Edge kinds in a ticket graph.
+const (
+	EdgeDep    = "dep"    // From depends on To
+	EdgeParent = "parent" // From is the parent of To
+	EdgeLink   = "link"   // From and To are linked (undirected)
+)
+
+// GraphEdge is an edge between two tickets of a graph.
+type GraphEdge struct {
+	From  string
+	To    string
+	Kind  string
+	Cycle bool // a dep edge that is part of a dependency cycle
+}
+
+// Graph is a set of tickets and the deps, parent/child relations and links
+// between them.
+type Graph struct {
+	Nodes []*Ticket
+	Edges []GraphEdge
+	cfg   *config.Config
+}
+
+// GraphOptions selects the tickets of a graph.
+type GraphOptions struct {
+	// Root limits the graph to the tickets reachable from this ID through deps
+	// and children. Empty means every ticket.
+	Root string
+	// IncludeDone keeps done tickets, which are left out by default.
+	IncludeDone bool
+}
+
+// BuildGraph builds the dependency graph of the tickets in dir. Edges whose ends
+// are not both in the graph are dropped, and dep edges that belong to a cycle
+// (as found by findCycles) are marked.
+func BuildGraph(dir string, opts GraphOptions) (*Graph, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	allTickets, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketMap := make(map[string]*Ticket)
+	for _, t := range allTickets {
+		if opts.IncludeDone || !cfg.IsDone(t.Status) {
+			ticketMap[t.ID] = t
+		}
+	}
+
+	if opts.Root != "" {
+		path, err := findTicketFile(dir, opts.Root)
+		if err != nil {
+			return nil, err
+		}
+		rootID := strings.TrimSuffix(filepath.Base(path), ".md")
+
+		children := make(map[string][]string)
+		for _, t := range allTickets {
+			if t.Parent != "" {
+				children[t.Parent] = append(children[t.Parent], t.ID)
+			}
+		}
+
+		reachable := make(map[string]*Ticket)
+		var visit func(id string)
+		visit = func(id string) {
+			t, ok := ticketMap[id]
+			if !ok || reachable[id] != nil {
+				return
+			}
+			reachable[id] = t
+			for _, dep := range t.Deps {
+				visit(dep)
+			}
+			for _, child := range children[id] {
+				visit(child)
+			}
+		}
+
+		// The root is always shown, even when it is done
+		for _, t := range allTickets {
+			if t.ID == rootID {
+				ticketMap[rootID] = t
+			}
+		}
+		if ticketMap[rootID] == nil {
+			return nil, fmt.Errorf("ticket not found: %s", opts.Root)
+		}
+		visit(rootID)
+		ticketMap = reachable
+	}
+
+	g := &Graph{cfg: cfg}
+	for _, t := range ticketMap {
+		g.Nodes = append(g.Nodes, t)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+
+	cycleEdges := make(map[[2]string]bool)
+	for _, cycle := range findCycles(ticketMap) {
+		for i, id := range cycle {
+			cycleEdges[[2]string{id, cycle[(i+1)%len(cycle)]}] = true
+		}
+	}
+
+	for _, t := range g.Nodes {
+		for _, dep := range t.Deps {
+			if ticketMap[dep] != nil {
+				g.Edges = append(g.Edges, GraphEdge{From: t.ID, To: dep, Kind: EdgeDep, Cycle: cycleEdges[[2]string{t.ID, dep}]})
+			}
+		}
+		if t.Parent != "" && ticketMap[t.Parent] != nil {
+			g.Edges = append(g.Edges, GraphEdge{From: t.Parent, To: t.ID, Kind: EdgeParent})
+		}
+		for _, link := range t.Links {
+			// Links are stored on both tickets; draw them once
+			if ticketMap[link] != nil && t.ID < link {
+				g.Edges = append(g.Edges, GraphEdge{From: t.ID, To: link, Kind: EdgeLink})
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// statusClass groups a ticket's status for coloring: "done", "in_progress",
+// "open" or "other" for custom statuses.
+func (g *Graph) statusClass(t *Ticket) string {
+	switch status := displayStatus(t.Status); {
+	case g.cfg.IsDone(t.Status):
+		return "done"
+	case status == "open" || status == "in_progress":
+		return status
+	default:
+		return "other"
+	}
+}
+
+// graphFill is the node fill color of each status class.
+var graphFill = map[string]string{
+	"open":        "#ffffff",
+	"in_progress": "#fff3b0",
+	"done":        "#d9d9d9",
+	"other":       "#dbeafe",
+}
+
+// graphBorder returns the node border color for a priority: red for P0, orange
+// for P1 and gray otherwise.
+func graphBorder(priority int) string {
+	switch priority {
+	case 0:
+		return "#d1242f"
+	case 1:
+		return "#e16f24"
+	default:
+		return "#57606a"
+	}
+}
+
+// graphLabel is the text of a node: "ID P<priority> Title".
+func graphLabel(t *Ticket) string {
+	return fmt.Sprintf("%s P%d %s", t.ID, t.Priority, t.Title)
+}
+
+// DOT renders the graph in Graphviz format. Nodes are filled by status and
+// bordered by priority; deps are solid arrows, parent/child edges dashed arrows,
+// links dotted lines, and dep edges in a cycle are red and bold.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph tickets {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	for _, t := range g.Nodes {
+		penwidth := 1
+		if t.Priority <= 1 {
+			penwidth = 2
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, fillcolor=%q, color=%q, penwidth=%d];\n",
+			dotQuote(t.ID), dotQuote(graphLabel(t)), graphFill[g.statusClass(t)], graphBorder(t.Priority), penwidth)
+	}
+
+	for _, e := range g.Edges {
+		var attrs []string
+		switch e.Kind {
+		case EdgeParent:
+			attrs = append(attrs, "style=dashed", "arrowhead=empty")
+		case EdgeLink:
+			attrs = append(attrs, "style=dotted", "dir=none")
+		}
+		if e.Cycle {
+			attrs = append(attrs, "color=\"#d1242f\"", "penwidth=2.5")
+		}
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(e.From), dotQuote(e.To))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}")
+	return b.String()
+}
+
+// dotQuote quotes a string as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", " ").Replace(s) + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart, with the same styles as DOT.
+// Node IDs are prefixed with "t_" so ticket IDs can't clash with Mermaid keywords.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, t := range g.Nodes {
+		fmt.Fprintf(&b, "  t_%s[\"%s\"]\n", t.ID, mermaidEscape(graphLabel(t)))
+	}
+
+	var cycleEdges []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		switch e.Kind {
+		case EdgeParent:
+			arrow = "-.->"
+		case EdgeLink:
+			arrow = "-.-"
+		}
+		fmt.Fprintf(&b, "  t_%s %s t_%s\n", e.From, arrow, e.To)
+		if e.Cycle {
+			cycleEdges = append(cycleEdges, fmt.Sprint(i))
+		}
+	}
+
+	for _, class := range []string{"open", "in_progress", "done", "other"} {
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", class, graphFill[class])
+	}
+	for p, name := range []string{"p0", "p1"} {
+		fmt.Fprintf(&b, "  classDef %s stroke:%s,stroke-width:2px\n", name, graphBorder(p))
+	}
+	for _, t := range g.Nodes {
+		classes := g.statusClass(t)
+		if t.Priority <= 1 {
+			classes += fmt.Sprintf(",p%d", t.Priority)
+		}
+		fmt.Fprintf(&b, "  class t_%s %s\n", t.ID, classes)
+	}
+	if len(cycleEdges) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:%s,stroke-width:3px\n", strings.Join(cycleEdges, ","), graphBorder(0))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// mermaidEscape escapes the characters Mermaid doesn't accept in quoted labels.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
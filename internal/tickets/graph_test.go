@@ -0,0 +1,116 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+)
+
+func graphFixture(t *testing.T) string {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Epic", Priority: 0})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Child", Parent: "aaa", Deps: []string{"ccc"}, Priority: 2})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Dep", Deps: []string{"bbb"}, Links: []string{"ddd"}, Status: "in_progress", Priority: 2})
+	writeFile(dir, &Ticket{ID: "ddd", Title: "Linked", Links: []string{"ccc"}, Priority: 2})
+	writeFile(dir, &Ticket{ID: "eee", Title: "Done", Status: "closed", Priority: 2})
+	return dir
+}
+
+func edgeStrings(g *Graph) []string {
+	var result []string
+	for _, e := range g.Edges {
+		s := e.From + "-" + e.Kind + "-" + e.To
+		if e.Cycle {
+			s += "!"
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func TestBuildGraph(t *testing.T) {
+	dir := graphFixture(t)
+
+	g, err := BuildGraph(dir, GraphOptions{})
+	if err != nil {
+		t.Fatalf("BuildGraph: %v", err)
+	}
+	if len(g.Nodes) != 4 {
+		t.Errorf("expected 4 nodes without the done ticket, got %d", len(g.Nodes))
+	}
+	got := strings.Join(edgeStrings(g), " ")
+	want := "bbb-dep-ccc! aaa-parent-bbb ccc-dep-bbb! ccc-link-ddd"
+	if got != want {
+		t.Errorf("edges = %q, want %q", got, want)
+	}
+
+	g, _ = BuildGraph(dir, GraphOptions{IncludeDone: true})
+	if len(g.Nodes) != 5 {
+		t.Errorf("expected 5 nodes with done tickets, got %d", len(g.Nodes))
+	}
+}
+
+func TestBuildGraphFromRoot(t *testing.T) {
+	dir := graphFixture(t)
+
+	g, err := BuildGraph(dir, GraphOptions{Root: "bbb"})
+	if err != nil {
+		t.Fatalf("BuildGraph: %v", err)
+	}
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.ID)
+	}
+	// ddd is only linked, so it is not reachable
+	if got := strings.Join(ids, ","); got != "bbb,ccc" {
+		t.Errorf("nodes = %q", got)
+	}
+
+	g, _ = BuildGraph(dir, GraphOptions{Root: "eee"})
+	if len(g.Nodes) != 1 {
+		t.Errorf("a done root should still be shown, got %d nodes", len(g.Nodes))
+	}
+
+	if _, err := BuildGraph(dir, GraphOptions{Root: "zzz"}); err == nil {
+		t.Error("expected an error for an unknown root")
+	}
+}
+
+func TestGraphDOT(t *testing.T) {
+	g, _ := BuildGraph(graphFixture(t), GraphOptions{})
+	out := g.DOT()
+
+	for _, want := range []string{
+		`digraph tickets {`,
+		`"aaa" [label="aaa P0 Epic", fillcolor="#ffffff", color="#d1242f", penwidth=2];`,
+		`"ccc" [label="ccc P2 Dep", fillcolor="#fff3b0"`,
+		`"bbb" -> "ccc" [color="#d1242f", penwidth=2.5];`,
+		`"aaa" -> "bbb" [style=dashed, arrowhead=empty];`,
+		`"ccc" -> "ddd" [style=dotted, dir=none];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraphMermaid(t *testing.T) {
+	g, _ := BuildGraph(graphFixture(t), GraphOptions{})
+	out := g.Mermaid()
+
+	for _, want := range []string{
+		"flowchart LR",
+		`t_aaa["aaa P0 Epic"]`,
+		"t_bbb --> t_ccc",
+		"t_aaa -.-> t_bbb",
+		"t_ccc -.- t_ddd",
+		"class t_aaa open,p0",
+		"class t_ccc in_progress",
+		"linkStyle 0,2 stroke:#d1242f,stroke-width:3px",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
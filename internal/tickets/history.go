@@ -0,0 +1,77 @@
+package tickets
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// HistoryEntry records a single change to a ticket field.
+type HistoryEntry struct {
+	At    string `yaml:"at" json:"at"`
+	Actor string `yaml:"actor,omitempty" json:"actor,omitempty"`
+	Field string `yaml:"field" json:"field"`
+	Old   string `yaml:"old,omitempty" json:"old,omitempty"`
+	New   string `yaml:"new,omitempty" json:"new,omitempty"`
+}
+
+// gitActor returns the git user.name configured for the given directory,
+// or an empty string if git is unavailable or the name is not set. It runs git,
+// so operations resolve it once and pass it to recordChange.
+func gitActor(dir string) string {
+	cmd := exec.Command("git", "config", "user.name")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// recordChange stamps the ticket's updated time and appends a history entry
+// by actor for a change to the given field.
+func recordChange(actor string, t *Ticket, field, old, new string) {
+	now := currentTimestamp()
+	t.Updated = now
+	t.History = append(t.History, HistoryEntry{
+		At:    now,
+		Actor: actor,
+		Field: field,
+		Old:   old,
+		New:   new,
+	})
+}
+
+// FormatHistory returns the ticket's history, one entry per line, oldest first.
+// Returns empty string if the ticket has no history.
+func FormatHistory(t *Ticket) string {
+	var b strings.Builder
+	for _, e := range t.History {
+		b.WriteString(formatHistoryEntry(e))
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// formatHistoryEntry renders a single entry as "at actor field: change".
+// Additions render as "+ new", removals as "- old" and replacements as "old -> new".
+func formatHistoryEntry(e HistoryEntry) string {
+	var change string
+	switch {
+	case e.Old == "" && e.New == "":
+		change = "changed"
+	case e.Old == "":
+		change = "+ " + e.New
+	case e.New == "":
+		change = "- " + e.Old
+	default:
+		change = fmt.Sprintf("%s -> %s", e.Old, e.New)
+	}
+
+	actor := e.Actor
+	if actor == "" {
+		actor = "unknown"
+	}
+
+	return fmt.Sprintf("%s %s %s: %s", e.At, actor, e.Field, change)
+}
@@ -0,0 +1,118 @@
+package tickets
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestHistoryRecordsChanges(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "B"})
+
+	if _, _, err := SetStatus(dir, "aaa", "in_progress"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := AddDep(dir, "aaa", "bbb"); err != nil {
+		t.Fatalf("AddDep: %v", err)
+	}
+	if err := RemoveDep(dir, "aaa", "bbb"); err != nil {
+		t.Fatalf("RemoveDep: %v", err)
+	}
+	if _, _, err := Done(dir, "aaa"); err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+
+	loaded, err := Show(dir, "aaa")
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+
+	want := []HistoryEntry{
+		{Field: "status", Old: "open", New: "in_progress"},
+		{Field: "deps", New: "bbb"},
+		{Field: "deps", Old: "bbb"},
+		{Field: "status", Old: "in_progress", New: "closed"},
+	}
+	if len(loaded.History) != len(want) {
+		t.Fatalf("history length = %d, want %d: %+v", len(loaded.History), len(want), loaded.History)
+	}
+	for i, w := range want {
+		got := loaded.History[i]
+		if got.Field != w.Field || got.Old != w.Old || got.New != w.New {
+			t.Errorf("entry %d = %+v, want field=%s old=%s new=%s", i, got, w.Field, w.Old, w.New)
+		}
+		if got.At == "" {
+			t.Errorf("entry %d has no timestamp", i)
+		}
+	}
+}
+
+func TestHistoryNoOpNotRecorded(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A", Status: "open"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "B"})
+
+	// Same status and removing an absent dep change nothing
+	SetStatus(dir, "aaa", "open")
+	RemoveDep(dir, "aaa", "bbb")
+	RemoveLink(dir, "aaa", "bbb")
+
+	loaded, _ := Show(dir, "aaa")
+	if len(loaded.History) != 0 {
+		t.Errorf("expected no history, got %+v", loaded.History)
+	}
+}
+
+func TestHistoryActorFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := tempDir(t)
+	EnsureDir(dir)
+	if err := exec.Command("git", "-C", dir, "init", "--quiet").Run(); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	if err := exec.Command("git", "-C", dir, "config", "user.name", "Alice").Run(); err != nil {
+		t.Fatalf("git config: %v", err)
+	}
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A"})
+	if _, _, err := SetStatus(dir, "aaa", "in_progress"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	loaded, _ := Show(dir, "aaa")
+	if len(loaded.History) != 1 || loaded.History[0].Actor != "Alice" {
+		t.Errorf("history = %+v, want actor Alice", loaded.History)
+	}
+}
+
+func TestFormatHistory(t *testing.T) {
+	ticket := &Ticket{ID: "aaa", History: []HistoryEntry{
+		{At: "2026-01-01T00:00:00Z", Actor: "Alice", Field: "status", Old: "open", New: "in_progress"},
+		{At: "2026-01-01T00:01:00Z", Actor: "Bob", Field: "deps", New: "bbb"},
+		{At: "2026-01-01T00:02:00Z", Field: "links", Old: "ccc"},
+		{At: "2026-01-01T00:03:00Z", Actor: "Bob", Field: "description"},
+	}}
+
+	want := "2026-01-01T00:00:00Z Alice status: open -> in_progress\n" +
+		"2026-01-01T00:01:00Z Bob deps: + bbb\n" +
+		"2026-01-01T00:02:00Z unknown links: - ccc\n" +
+		"2026-01-01T00:03:00Z Bob description: changed"
+
+	if got := FormatHistory(ticket); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatHistoryEmpty(t *testing.T) {
+	if got := FormatHistory(&Ticket{ID: "aaa"}); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+}
@@ -0,0 +1,172 @@
+package tickets
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// Impact describes what closing a ticket would change for the tickets that
+// depend on it. Only tickets that are not done are counted.
+type Impact struct {
+	Ticket *Ticket
+	// Unblocked are the tickets whose only unfinished dep is Ticket: they become
+	// ready when it is done.
+	Unblocked []*Ticket
+	// Blocked are all tickets that depend on Ticket, directly or transitively.
+	Blocked []*Ticket
+	// UnblockedWeight and BlockedWeight sum PriorityWeight over each list.
+	UnblockedWeight int
+	BlockedWeight   int
+}
+
+// PriorityWeight returns how much a ticket counts in impact sums: 1 for the
+// lowest configured priority, one more for each level above it.
+func PriorityWeight(priority int, cfg *config.Config) int {
+	w := cfg.Priority.Max - priority + 1
+	if w < 1 {
+		return 1
+	}
+	return w
+}
+
+// ComputeImpact computes the impact of closing the given ticket. A done ticket
+// blocks nothing and has no impact.
+func ComputeImpact(dir string, id string) (*Impact, error) {
+	path, err := findTicketFile(dir, id)
+	if err != nil {
+		return nil, err
+	}
+	resolvedID := strings.TrimSuffix(filepath.Base(path), ".md")
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	allTickets, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newImpactGraph(allTickets, cfg)
+	for _, t := range allTickets {
+		if t.ID == resolvedID {
+			return g.impact(t), nil
+		}
+	}
+	return nil, fmt.Errorf("ticket not found: %s", id)
+}
+
+// RankImpact computes the impact of every ticket that blocks at least one other
+// and sorts them by unblocked weight, then blocked weight (both descending), then ID.
+func RankImpact(dir string) ([]*Impact, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	allTickets, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newImpactGraph(allTickets, cfg)
+	var result []*Impact
+	for _, t := range allTickets {
+		if imp := g.impact(t); len(imp.Blocked) > 0 {
+			result = append(result, imp)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.UnblockedWeight != b.UnblockedWeight {
+			return a.UnblockedWeight > b.UnblockedWeight
+		}
+		if a.BlockedWeight != b.BlockedWeight {
+			return a.BlockedWeight > b.BlockedWeight
+		}
+		return a.Ticket.ID < b.Ticket.ID
+	})
+	return result, nil
+}
+
+// impactGraph indexes the unfinished tickets and who depends on them.
+type impactGraph struct {
+	open       map[string]*Ticket
+	dependents map[string][]*Ticket
+	cfg        *config.Config
+}
+
+func newImpactGraph(allTickets []*Ticket, cfg *config.Config) *impactGraph {
+	g := &impactGraph{
+		open:       make(map[string]*Ticket),
+		dependents: make(map[string][]*Ticket),
+		cfg:        cfg,
+	}
+	for _, t := range allTickets {
+		if !cfg.IsDone(t.Status) {
+			g.open[t.ID] = t
+		}
+	}
+	for _, t := range allTickets {
+		if g.open[t.ID] == nil {
+			continue
+		}
+		for _, depID := range t.Deps {
+			g.dependents[depID] = append(g.dependents[depID], t)
+		}
+	}
+	return g
+}
+
+// impact computes the Impact of closing t.
+func (g *impactGraph) impact(t *Ticket) *Impact {
+	imp := &Impact{Ticket: t}
+	if g.open[t.ID] == nil {
+		return imp
+	}
+
+	for _, d := range g.dependents[t.ID] {
+		if g.onlyBlocker(d, t.ID) {
+			imp.Unblocked = append(imp.Unblocked, d)
+			imp.UnblockedWeight += PriorityWeight(d.Priority, g.cfg)
+		}
+	}
+
+	visited := map[string]bool{t.ID: true}
+	queue := []string{t.ID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, d := range g.dependents[id] {
+			if visited[d.ID] {
+				continue
+			}
+			visited[d.ID] = true
+			imp.Blocked = append(imp.Blocked, d)
+			imp.BlockedWeight += PriorityWeight(d.Priority, g.cfg)
+			queue = append(queue, d.ID)
+		}
+	}
+
+	byPriority := []SortKey{{Field: "priority"}}
+	SortTickets(imp.Unblocked, byPriority)
+	SortTickets(imp.Blocked, byPriority)
+	return imp
+}
+
+// onlyBlocker reports whether id is the only unfinished dep of t. Missing deps
+// don't block, as in `todo ready`.
+func (g *impactGraph) onlyBlocker(t *Ticket, id string) bool {
+	for _, depID := range t.Deps {
+		if depID != id && g.open[depID] != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+func impactIDs(items []*Ticket) string {
+	var ids []string
+	for _, t := range items {
+		ids = append(ids, t.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+func impactFixture(t *testing.T) string {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Blocker", Priority: 2})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Only blocked by aaa", Deps: []string{"aaa", "zzz"}, Priority: 0})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Also blocked by ddd", Deps: []string{"aaa", "ddd"}, Priority: 2})
+	writeFile(dir, &Ticket{ID: "ddd", Title: "Other blocker", Priority: 2})
+	writeFile(dir, &Ticket{ID: "eee", Title: "Downstream", Deps: []string{"bbb"}, Priority: 4})
+	writeFile(dir, &Ticket{ID: "fff", Title: "Done", Deps: []string{"aaa"}, Status: "closed", Priority: 0})
+	return dir
+}
+
+func TestComputeImpact(t *testing.T) {
+	dir := impactFixture(t)
+
+	imp, err := ComputeImpact(dir, "aaa")
+	if err != nil {
+		t.Fatalf("ComputeImpact: %v", err)
+	}
+	// zzz is missing and doesn't block; fff is done and isn't counted
+	if got := impactIDs(imp.Unblocked); got != "bbb" {
+		t.Errorf("unblocked = %q, want bbb", got)
+	}
+	if got := impactIDs(imp.Blocked); got != "bbb,ccc,eee" {
+		t.Errorf("blocked = %q, want bbb,ccc,eee", got)
+	}
+	if imp.UnblockedWeight != 5 || imp.BlockedWeight != 5+3+1 {
+		t.Errorf("weights = %d, %d", imp.UnblockedWeight, imp.BlockedWeight)
+	}
+
+	imp, _ = ComputeImpact(dir, "fff")
+	if len(imp.Blocked) != 0 || len(imp.Unblocked) != 0 {
+		t.Errorf("a done ticket should have no impact: %+v", imp)
+	}
+
+	if _, err := ComputeImpact(dir, "xyz"); err == nil {
+		t.Error("expected an error for an unknown ticket")
+	}
+}
+
+func TestRankImpact(t *testing.T) {
+	ranked, err := RankImpact(impactFixture(t))
+	if err != nil {
+		t.Fatalf("RankImpact: %v", err)
+	}
+
+	var ids []string
+	for _, imp := range ranked {
+		ids = append(ids, imp.Ticket.ID)
+	}
+	// aaa unblocks a P0, bbb unblocks a P4, ddd unblocks nothing (ccc still waits on aaa)
+	if got := strings.Join(ids, ","); got != "aaa,bbb,ddd" {
+		t.Errorf("ranking = %q, want aaa,bbb,ddd", got)
+	}
+}
+
+func TestPriorityWeight(t *testing.T) {
+	cfg := config.Default()
+	if PriorityWeight(0, cfg) != 5 || PriorityWeight(4, cfg) != 1 || PriorityWeight(9, cfg) != 1 {
+		t.Error("weights should go from 5 (P0) down to 1 (P4) and never below 1")
+	}
+}
@@ -0,0 +1,555 @@
+package tickets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// ImportOptions controls how Import matches and writes tickets.
+type ImportOptions struct {
+	// NewIDs gives every imported ticket a fresh ID and rewrites deps, links,
+	// parents and history entries that point at other imported tickets. Nothing
+	// is updated.
+	NewIDs bool
+	// DryRun computes the result without writing anything.
+	DryRun bool
+	// ByExternalRef matches records to tickets by external_ref instead of ID: a
+	// record updates the ticket with the same external_ref, or creates a ticket
+	// with a generated ID. Used to re-run imports from other trackers, so updates
+	// keep the local status unless the ticket was closed or reopened in the
+	// tracker, and add the record's tags to the local ones. Records matching an
+	// archived ticket are skipped.
+	ByExternalRef bool
+}
+
+// ImportRecord is a ticket to import and the fields its input has.
+type ImportRecord struct {
+	*Ticket
+	// Fields lists the fields present in the input, named like in todo query
+	// ("title", "external_ref", ...). Updates leave the other fields alone, and
+	// created tickets get the configured default type and priority when those are
+	// missing. Nil means every field is present.
+	Fields []string
+}
+
+// has reports whether the record's input has the field.
+func (r ImportRecord) has(field string) bool {
+	return r.Fields == nil || containsString(r.Fields, field)
+}
+
+// FieldDiff is a change to one field of an updated ticket. Free-text fields
+// (description, design, acceptance) only report that they changed.
+type FieldDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// ImportAction describes what Import did (or would do) with one imported ticket.
+type ImportAction struct {
+	Ticket   *Ticket
+	SourceID string // the ID in the input, when it differs from Ticket.ID
+	Created  bool
+	Archived bool        // the record matched an archived ticket and was skipped
+	Changes  []FieldDiff // for updates; empty when the ticket was unchanged
+}
+
+// validID matches IDs that are safe to use as ticket file names.
+var validID = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+
+// Import creates or updates tickets from the given records, in order. A record whose
+// ID matches an existing ticket updates the fields present in the record (recording
+// each change in its history); any other record creates a ticket with that ID, or a
+// generated one if it has none. Types, statuses, priorities and status transitions
+// are validated against the config, and parents, deps and links must point at
+// existing or imported tickets. Links added or removed by a record are mirrored on
+// the other ticket. Nothing is written unless every record is valid.
+func Import(dir string, records []ImportRecord, opts ImportOptions) ([]ImportAction, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := EnsureDir(dir); err != nil {
+		return nil, err
+	}
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	entries, warnings, err := listEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := archivedIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]ticketEntry)
+	taken := make(map[string]bool) // IDs that can't be generated
+	for _, e := range entries {
+		existing[e.id] = e
+		taken[e.id] = true
+	}
+	for _, w := range warnings {
+		taken[w.ID] = true
+	}
+	for id := range archived {
+		taken[id] = true
+	}
+
+	var skipped map[int]*Ticket // records matching an archived ticket
+	if opts.ByExternalRef {
+		archivedTickets, err := ListArchived(dir)
+		if err != nil {
+			return nil, err
+		}
+		if skipped, err = matchExternalRefs(records, entries, archivedTickets); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check the IDs given in the input, and reserve them unless they are replaced
+	inputIDs := make([]string, len(records))
+	seen := make(map[string]bool)
+	for i, r := range records {
+		inputIDs[i] = r.ID
+		if r.ID == "" {
+			continue
+		}
+		if !validID.MatchString(r.ID) {
+			return nil, fmt.Errorf("record %d: invalid id %q: must be alphanumeric", i+1, r.ID)
+		}
+		if seen[r.ID] {
+			return nil, fmt.Errorf("record %d: duplicate id %s", i+1, r.ID)
+		}
+		seen[r.ID] = true
+
+		if opts.NewIDs {
+			continue
+		}
+		if archived[r.ID] {
+			return nil, fmt.Errorf("record %d: ticket %s is archived", i+1, r.ID)
+		}
+		if _, ok := existing[r.ID]; !ok && taken[r.ID] {
+			return nil, fmt.Errorf("record %d: ticket file %s can't be parsed", i+1, ticketFileName(r.ID))
+		}
+		taken[r.ID] = true
+	}
+
+	// Generate the missing IDs, and every ID with NewIDs
+	remap := make(map[string]string) // input ID -> final ID
+	sourceIDs := make([]string, len(records))
+	for i, r := range records {
+		if skipped[i] != nil || !opts.NewIDs && r.ID != "" {
+			continue
+		}
+		id := generateUniqueID(taken)
+		taken[id] = true
+		if r.ID != "" {
+			remap[r.ID] = id
+			sourceIDs[i] = r.ID
+		}
+		r.ID = id
+	}
+
+	// IDs that references may point at: existing tickets and every imported one
+	known := make(map[string]bool)
+	for id := range existing {
+		known[id] = true
+	}
+	for i, r := range records {
+		if skipped[i] == nil {
+			known[r.ID] = true
+		}
+	}
+
+	recordError := func(i int, err error) error {
+		if inputIDs[i] != "" {
+			return fmt.Errorf("record %d (%s): %w", i+1, inputIDs[i], err)
+		}
+		return fmt.Errorf("record %d: %w", i+1, err)
+	}
+
+	var actions []ImportAction
+	oldLinks := make([][]string, len(records)) // links of each ticket before the import
+	for i, r := range records {
+		if t := skipped[i]; t != nil {
+			actions = append(actions, ImportAction{Ticket: t, Archived: true})
+			continue
+		}
+
+		r.Deps = remapIDs(r.Deps, remap)
+		r.Links = remapIDs(r.Links, remap)
+		if newID, ok := remap[r.Parent]; ok {
+			r.Parent = newID
+		}
+		remapHistory(r.History, remap)
+
+		e, update := existing[r.ID]
+		update = update && !opts.NewIDs
+		if !update {
+			if !r.has("type") || r.Type == "" {
+				r.Type = cfg.Defaults.Type
+			}
+			if !r.has("priority") {
+				r.Priority = cfg.Defaults.Priority
+			}
+			r.Fields = nil
+		}
+
+		if err := validateRecord(r, known, cfg); err != nil {
+			return nil, recordError(i, err)
+		}
+
+		if update {
+			if opts.ByExternalRef {
+				keepLocalState(r, e.ticket, cfg)
+			}
+			oldLinks[i] = append([]string(nil), e.ticket.Links...)
+			changes, err := mergeRecord(actor, e.ticket, r, cfg)
+			if err != nil {
+				return nil, recordError(i, err)
+			}
+			actions = append(actions, ImportAction{Ticket: e.ticket, Changes: changes})
+			continue
+		}
+
+		if r.Created == "" {
+			r.Created = currentTimestamp()
+		}
+		actions = append(actions, ImportAction{Ticket: r.Ticket, SourceID: sourceIDs[i], Created: true})
+	}
+
+	actions = mirrorLinks(actor, actions, oldLinks, existing)
+
+	if opts.DryRun {
+		return actions, nil
+	}
+
+	for _, a := range actions {
+		if !a.Created && len(a.Changes) == 0 {
+			continue
+		}
+		path := existing[a.Ticket.ID].path
+		if a.Created {
+			path, err = ticketFilePath(dir, a.Ticket.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := atomicWriteFile(path, []byte(a.Ticket.FullString())); err != nil {
+			return nil, err
+		}
+	}
+
+	return actions, nil
+}
+
+// mirrorLinks keeps links bidirectional: the links each imported ticket gained or
+// lost are added to or removed from the other ticket, recording the change in its
+// history. Existing tickets changed this way are appended to the actions.
+func mirrorLinks(actor string, actions []ImportAction, oldLinks [][]string, existing map[string]ticketEntry) []ImportAction {
+	index := make(map[string]int) // ticket ID -> action
+	for i, a := range actions {
+		index[a.Ticket.ID] = i
+	}
+
+	mirror := func(id, target string, add bool) {
+		var t *Ticket
+		if i, ok := index[id]; ok {
+			t = actions[i].Ticket
+		} else if e, ok := existing[id]; ok {
+			t = e.ticket
+		}
+		if t == nil || id == target || containsString(t.Links, target) == add {
+			return
+		}
+
+		i, ok := index[id]
+		if !ok {
+			actions = append(actions, ImportAction{Ticket: t})
+			i = len(actions) - 1
+			index[id] = i
+		}
+		a := &actions[i]
+
+		old := strings.Join(t.Links, ",")
+		if add {
+			t.Links = append(t.Links, target)
+			recordChange(actor, t, "links", "", target)
+		} else {
+			t.Links = removeString(t.Links, target)
+			recordChange(actor, t, "links", target, "")
+		}
+		if a.Created {
+			return
+		}
+		for j := range a.Changes {
+			if a.Changes[j].Field == "links" {
+				a.Changes[j].New = strings.Join(t.Links, ",")
+				return
+			}
+		}
+		a.Changes = append(a.Changes, FieldDiff{Field: "links", Old: old, New: strings.Join(t.Links, ",")})
+	}
+
+	for i := range oldLinks {
+		if actions[i].Archived {
+			continue
+		}
+		t := actions[i].Ticket
+		for _, id := range t.Links {
+			if !containsString(oldLinks[i], id) {
+				mirror(id, t.ID, true)
+			}
+		}
+		for _, id := range oldLinks[i] {
+			if !containsString(t.Links, id) {
+				mirror(id, t.ID, false)
+			}
+		}
+	}
+	return actions
+}
+
+// keepLocalState adjusts a record from another tracker so it doesn't undo local
+// work: the status only changes when the record is done and the ticket isn't, or
+// the other way around, and the record's tags are added to the ticket's.
+func keepLocalState(r ImportRecord, t *Ticket, cfg *config.Config) {
+	if r.has("status") && cfg.IsDone(displayStatus(r.Status)) == cfg.IsDone(displayStatus(t.Status)) {
+		r.Status = t.Status
+	}
+	if r.has("tags") {
+		tags := append([]string(nil), t.Tags...)
+		for _, tag := range r.Tags {
+			if !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		r.Tags = tags
+	}
+}
+
+// matchExternalRefs sets the ID of each record to the ticket with the same
+// external_ref, or clears it when there is none. Records whose external_ref
+// belongs to an archived ticket are returned by index, so they aren't imported
+// again as new tickets.
+func matchExternalRefs(records []ImportRecord, entries []ticketEntry, archived []*Ticket) (map[int]*Ticket, error) {
+	byRef := make(map[string]string)
+	for _, e := range entries {
+		if e.ticket.ExternalRef != "" {
+			byRef[e.ticket.ExternalRef] = e.id
+		}
+	}
+	archivedByRef := make(map[string]*Ticket)
+	for _, t := range archived {
+		if t.ExternalRef != "" {
+			archivedByRef[t.ExternalRef] = t
+		}
+	}
+
+	skipped := make(map[int]*Ticket)
+	seen := make(map[string]bool)
+	for i, r := range records {
+		if r.ExternalRef == "" {
+			return nil, fmt.Errorf("record %d: missing external_ref", i+1)
+		}
+		if seen[r.ExternalRef] {
+			return nil, fmt.Errorf("record %d: duplicate external_ref %s", i+1, r.ExternalRef)
+		}
+		seen[r.ExternalRef] = true
+		r.ID = byRef[r.ExternalRef]
+		if t, ok := archivedByRef[r.ExternalRef]; ok && r.ID == "" {
+			skipped[i] = t
+		}
+	}
+	return skipped, nil
+}
+
+// historyIDFields are the history fields whose old and new values are ticket IDs.
+var historyIDFields = []string{"id", "deps", "links", "parent"}
+
+// remapHistory rewrites the ticket IDs in history entries, so the history of an
+// imported ticket names the new IDs its deps, links and parent were given.
+func remapHistory(history []HistoryEntry, remap map[string]string) {
+	for i := range history {
+		e := &history[i]
+		if !containsString(historyIDFields, e.Field) {
+			continue
+		}
+		if newID, ok := remap[e.Old]; ok {
+			e.Old = newID
+		}
+		if newID, ok := remap[e.New]; ok {
+			e.New = newID
+		}
+	}
+}
+
+// remapIDs rewrites the IDs found in remap and leaves the others unchanged.
+func remapIDs(ids []string, remap map[string]string) []string {
+	var result []string
+	for _, id := range ids {
+		if newID, ok := remap[id]; ok {
+			id = newID
+		}
+		result = append(result, id)
+	}
+	return result
+}
+
+// validateRecord checks the fields and references present in an imported record.
+func validateRecord(r ImportRecord, known map[string]bool, cfg *config.Config) error {
+	if r.has("title") && strings.TrimSpace(r.Title) == "" {
+		return fmt.Errorf("title must not be empty")
+	}
+	if r.has("type") {
+		if err := cfg.CheckType(r.Type); err != nil {
+			return err
+		}
+	}
+	if r.has("status") && r.Status != "" {
+		if err := cfg.CheckStatus(r.Status); err != nil {
+			return err
+		}
+	}
+	if r.has("priority") {
+		if err := cfg.CheckPriority(r.Priority); err != nil {
+			return err
+		}
+	}
+	for _, d := range []string{r.Estimate, r.Spent} {
+		if d != "" {
+			if _, err := ParseEstimate(d); err != nil {
+				return err
+			}
+		}
+	}
+	for _, d := range []string{r.Due, r.Scheduled} {
+		if d != "" {
+			if _, err := time.Parse(DateLayout, d); err != nil {
+				return fmt.Errorf("invalid date %q: expected YYYY-MM-DD", d)
+			}
+		}
+	}
+	if r.Recur != "" {
+		if _, err := ParseRecurrence(r.Recur); err != nil {
+			return err
+		}
+	}
+
+	if r.Parent != "" {
+		if !known[r.Parent] {
+			return fmt.Errorf("parent %s does not exist", r.Parent)
+		}
+		if r.Parent == r.ID {
+			return fmt.Errorf("a ticket cannot be its own parent")
+		}
+	}
+	for _, id := range r.Deps {
+		if !known[id] {
+			return fmt.Errorf("dep %s does not exist", id)
+		}
+	}
+	for _, id := range r.Links {
+		if !known[id] {
+			return fmt.Errorf("link %s does not exist", id)
+		}
+	}
+	return nil
+}
+
+// mergeRecord applies the editable fields present in an imported record to an
+// existing ticket, recording each change in its history, and returns the changes.
+// Status changes must follow the configured transitions. Timestamps and history
+// come from the existing ticket.
+func mergeRecord(actor string, t *Ticket, r ImportRecord, cfg *config.Config) ([]FieldDiff, error) {
+	var diffs []FieldDiff
+	note := func(field, old, new string) {
+		diffs = append(diffs, FieldDiff{Field: field, Old: old, New: new})
+	}
+	want := r.has
+
+	single := func(field string, dst *string, value string) {
+		if want(field) && *dst != value {
+			note(field, *dst, value)
+			setField(actor, t, field, dst, value)
+		}
+	}
+	text := func(field string, dst *string, value string) {
+		if want(field) && *dst != value {
+			note(field, "", "")
+			setTextField(actor, t, field, dst, value)
+		}
+	}
+
+	single("title", &t.Title, r.Title)
+	if want("status") && displayStatus(t.Status) != displayStatus(r.Status) {
+		if err := cfg.CheckTransition(t.Status, displayStatus(r.Status)); err != nil {
+			return nil, err
+		}
+		note("status", displayStatus(t.Status), displayStatus(r.Status))
+		setStatus(actor, t, displayStatus(r.Status), cfg)
+	}
+	single("type", &t.Type, r.Type)
+	if want("priority") && t.Priority != r.Priority {
+		note("priority", strconv.Itoa(t.Priority), strconv.Itoa(r.Priority))
+		recordChange(actor, t, "priority", strconv.Itoa(t.Priority), strconv.Itoa(r.Priority))
+		t.Priority = r.Priority
+	}
+	single("assignee", &t.Assignee, r.Assignee)
+	single("parent", &t.Parent, r.Parent)
+	single("external_ref", &t.ExternalRef, r.ExternalRef)
+	single("estimate", &t.Estimate, r.Estimate)
+	single("spent", &t.Spent, r.Spent)
+	single("due", &t.Due, r.Due)
+	single("scheduled", &t.Scheduled, r.Scheduled)
+	single("recur", &t.Recur, r.Recur)
+	text("design", &t.Design, r.Design)
+	text("acceptance", &t.Acceptance, r.Acceptance)
+	if want("description") && t.Description != r.Description {
+		note("description", "", "")
+		recordChange(actor, t, "description", "", "")
+		t.Description = r.Description
+	}
+
+	for _, list := range []struct {
+		field string
+		dst   *[]string
+		value []string
+	}{
+		{"deps", &t.Deps, r.Deps},
+		{"links", &t.Links, r.Links},
+		{"tags", &t.Tags, r.Tags},
+	} {
+		if !want(list.field) || strings.Join(*list.dst, ",") == strings.Join(list.value, ",") {
+			continue
+		}
+		note(list.field, strings.Join(*list.dst, ","), strings.Join(list.value, ","))
+		for _, v := range *list.dst {
+			if !containsString(list.value, v) {
+				recordChange(actor, t, list.field, v, "")
+			}
+		}
+		for _, v := range list.value {
+			if !containsString(*list.dst, v) {
+				recordChange(actor, t, list.field, "", v)
+			}
+		}
+		*list.dst = list.value
+	}
+
+	return diffs, nil
+}
@@ -0,0 +1,290 @@
+package tickets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// asRecords wraps tickets as import records with every field present.
+func asRecords(items []*Ticket) []ImportRecord {
+	var records []ImportRecord
+	for _, t := range items {
+		records = append(records, ImportRecord{Ticket: t})
+	}
+	return records
+}
+
+func TestImportCreatesAndUpdates(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Existing", Type: "task", Priority: 2, Tags: []string{"ui"}, Created: "2026-01-01T00:00:00Z"})
+
+	actions, err := Import(dir, asRecords([]*Ticket{
+		{ID: "aaa", Title: "Existing", Type: "task", Priority: 1, Tags: []string{"ui", "perf"}, Status: "in_progress"},
+		{ID: "bbb", Title: "New", Deps: []string{"aaa"}},
+		{Title: "No ID"},
+	}), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(actions) != 3 {
+		t.Fatalf("got %d actions, want 3", len(actions))
+	}
+
+	if actions[0].Created {
+		t.Error("aaa should be updated, not created")
+	}
+	var fields []string
+	for _, c := range actions[0].Changes {
+		fields = append(fields, c.Field+":"+c.Old+"->"+c.New)
+	}
+	if got := strings.Join(fields, " "); got != "status:open->in_progress priority:2->1 tags:ui->ui,perf" {
+		t.Errorf("changes = %q", got)
+	}
+
+	updated, _ := Show(dir, "aaa")
+	if updated.Priority != 1 || updated.Status != "in_progress" || updated.StartedAt == "" {
+		t.Errorf("update not applied: %+v", updated)
+	}
+	if updated.Created != "2026-01-01T00:00:00Z" {
+		t.Errorf("created should be kept, got %q", updated.Created)
+	}
+	if len(updated.History) != 3 {
+		t.Errorf("expected 3 history entries, got %d", len(updated.History))
+	}
+
+	created, err := Show(dir, "bbb")
+	if err != nil {
+		t.Fatalf("Show bbb: %v", err)
+	}
+	if created.Title != "New" || created.Created == "" || len(created.Deps) != 1 {
+		t.Errorf("created ticket = %+v", created)
+	}
+
+	if !actions[2].Created || len(actions[2].Ticket.ID) != 3 {
+		t.Errorf("ticket without ID should be created with a generated ID: %+v", actions[2])
+	}
+}
+
+func TestImportUnchanged(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Same", Type: "task", Priority: 2})
+
+	actions, err := Import(dir, asRecords([]*Ticket{{ID: "aaa", Title: "Same", Type: "task", Priority: 2, Status: "open"}}), ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if actions[0].Created || len(actions[0].Changes) != 0 {
+		t.Errorf("expected no changes, got %+v", actions[0])
+	}
+
+	same, _ := Show(dir, "aaa")
+	if same.History != nil || same.Updated != "" {
+		t.Errorf("unchanged ticket should not be rewritten: %+v", same)
+	}
+}
+
+func TestImportNewIDs(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Unrelated"})
+
+	actions, err := Import(dir, asRecords([]*Ticket{
+		{ID: "aaa", Title: "Parent"},
+		{ID: "bbb", Title: "Child", Parent: "aaa", Deps: []string{"aaa"}, Links: []string{"aaa"}, History: []HistoryEntry{
+			{Field: "parent", New: "aaa"},
+			{Field: "deps", Old: "zzz"},
+			{Field: "title", Old: "aaa", New: "Child"},
+		}},
+	}), ImportOptions{NewIDs: true})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	parent, child := actions[0], actions[1]
+	if !parent.Created || parent.Ticket.ID == "aaa" || parent.SourceID != "aaa" {
+		t.Errorf("parent action = %+v", parent)
+	}
+	if child.Ticket.Parent != parent.Ticket.ID || child.Ticket.Deps[0] != parent.Ticket.ID || child.Ticket.Links[0] != parent.Ticket.ID {
+		t.Errorf("references not remapped: %+v", child.Ticket)
+	}
+	history := child.Ticket.History
+	if history[0].New != parent.Ticket.ID || history[1].Old != "zzz" || history[2].Old != "aaa" {
+		t.Errorf("history should name the new IDs of imported tickets only: %+v", history)
+	}
+
+	unrelated, _ := Show(dir, "aaa")
+	if unrelated.Title != "Unrelated" {
+		t.Errorf("existing ticket was modified: %+v", unrelated)
+	}
+	all, _ := List(dir)
+	if len(all) != 3 {
+		t.Errorf("expected 3 tickets, got %d", len(all))
+	}
+}
+
+func TestImportValidationWritesNothing(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Existing", Type: "task", Priority: 2})
+
+	tests := []struct {
+		records []*Ticket
+		want    string
+	}{
+		{[]*Ticket{{ID: "aaa", Title: "Existing", Type: "task", Priority: 1}, {ID: "bbb", Title: ""}}, "record 2 (bbb): title must not be empty"},
+		{[]*Ticket{{Title: "Typed", Type: "weird"}}, `record 1: invalid type "weird"`},
+		{[]*Ticket{{Title: "Prio", Priority: 9}}, "record 1: invalid priority 9"},
+		{[]*Ticket{{Title: "Dep", Deps: []string{"zzz"}}}, "dep zzz does not exist"},
+		{[]*Ticket{{Title: "Parent", Parent: "zzz"}}, "parent zzz does not exist"},
+		{[]*Ticket{{ID: "a/b", Title: "Bad"}}, `invalid id "a/b"`},
+		{[]*Ticket{{ID: "ccc", Title: "One"}, {ID: "ccc", Title: "Two"}}, "record 2: duplicate id ccc"},
+	}
+
+	for _, tt := range tests {
+		_, err := Import(dir, asRecords(tt.records), ImportOptions{})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("error = %v, want it to contain %q", err, tt.want)
+		}
+	}
+
+	existing, _ := Show(dir, "aaa")
+	if existing.Priority != 2 {
+		t.Errorf("ticket modified by a failed import: %+v", existing)
+	}
+	all, _ := List(dir)
+	if len(all) != 1 {
+		t.Errorf("failed imports created tickets: %d", len(all))
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Existing", Type: "task"})
+
+	actions, err := Import(dir, asRecords([]*Ticket{
+		{ID: "aaa", Title: "Renamed", Type: "task"},
+		{ID: "bbb", Title: "New"},
+	}), ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(actions[0].Changes) != 1 || !actions[1].Created {
+		t.Errorf("unexpected actions: %+v", actions)
+	}
+
+	existing, _ := Show(dir, "aaa")
+	if existing.Title != "Existing" {
+		t.Errorf("dry run modified a ticket: %+v", existing)
+	}
+	if _, err := Show(dir, "bbb"); err == nil {
+		t.Error("dry run created a ticket")
+	}
+}
+
+func TestImportPartialRecords(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte("defaults:\n  type: feature\n  priority: 1\n"), 0644)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Existing", Type: "bug", Priority: 3, Assignee: "Alice", Tags: []string{"ui"}})
+
+	actions, err := Import(dir, []ImportRecord{
+		{Ticket: &Ticket{ID: "aaa", Title: "Renamed"}, Fields: []string{"id", "title"}},
+		{Ticket: &Ticket{ID: "bbb", Title: "New"}, Fields: []string{"id", "title"}},
+	}, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(actions[0].Changes) != 1 {
+		t.Errorf("expected only the title to change, got %+v", actions[0].Changes)
+	}
+
+	updated, _ := Show(dir, "aaa")
+	if updated.Title != "Renamed" || updated.Type != "bug" || updated.Priority != 3 || updated.Assignee != "Alice" || len(updated.Tags) != 1 {
+		t.Errorf("fields missing from the record were changed: %+v", updated)
+	}
+
+	created, _ := Show(dir, "bbb")
+	if created.Type != "feature" || created.Priority != 1 {
+		t.Errorf("created ticket should get the default type and priority: %+v", created)
+	}
+}
+
+func TestImportRejectsEmptyTypeAndTransitions(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	os.WriteFile(filepath.Join(dir, ".todo.yaml"), []byte(`transitions:
+  open: [in_progress]
+  in_progress: [open, closed]
+  closed: [open]
+`), 0644)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Existing", Type: "task"})
+
+	tests := []struct {
+		record ImportRecord
+		want   string
+	}{
+		{ImportRecord{Ticket: &Ticket{ID: "aaa", Type: ""}, Fields: []string{"id", "type"}}, `record 1 (aaa): invalid type ""`},
+		{ImportRecord{Ticket: &Ticket{ID: "aaa", Status: "closed"}, Fields: []string{"id", "status"}}, `record 1 (aaa): cannot change status from "open" to "closed"`},
+	}
+
+	for _, tt := range tests {
+		_, err := Import(dir, []ImportRecord{tt.record}, ImportOptions{})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("error = %v, want it to contain %q", err, tt.want)
+		}
+	}
+
+	existing, _ := Show(dir, "aaa")
+	if existing.Type != "task" || displayStatus(existing.Status) != "open" {
+		t.Errorf("ticket modified by a failed import: %+v", existing)
+	}
+}
+
+func TestImportMirrorsLinks(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A", Type: "task", Links: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "B", Type: "task", Links: []string{"aaa"}})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "C", Type: "task"})
+
+	actions, err := Import(dir, []ImportRecord{
+		{Ticket: &Ticket{ID: "aaa", Links: []string{"ccc"}}, Fields: []string{"id", "links"}},
+		{Ticket: &Ticket{ID: "ddd", Title: "D", Links: []string{"ccc"}}},
+	}, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	var ids []string
+	for _, a := range actions {
+		ids = append(ids, a.Ticket.ID)
+	}
+	if got := strings.Join(ids, " "); got != "aaa ddd ccc bbb" {
+		t.Errorf("actions = %q, want the back-linked tickets appended", got)
+	}
+
+	for id, want := range map[string]string{"aaa": "ccc", "bbb": "", "ccc": "aaa,ddd", "ddd": "ccc"} {
+		ticket, _ := Show(dir, id)
+		if got := strings.Join(ticket.Links, ","); got != want {
+			t.Errorf("%s links = %q, want %q", id, got, want)
+		}
+	}
+
+	c, _ := Show(dir, "ccc")
+	if len(c.History) != 2 {
+		t.Errorf("expected 2 history entries on ccc, got %d", len(c.History))
+	}
+}
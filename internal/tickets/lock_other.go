@@ -0,0 +1,16 @@
+//go:build !unix
+
+package tickets
+
+import "sync"
+
+// dirMu serializes read-modify-write operations within this process on
+// platforms without flock. It does not protect against other processes.
+var dirMu sync.Mutex
+
+// lockDir takes an exclusive lock for read-modify-write operations on the
+// tickets directory. Returns a function that releases the lock.
+func lockDir(dir string) (func(), error) {
+	dirMu.Lock()
+	return dirMu.Unlock, nil
+}
@@ -0,0 +1,176 @@
+package tickets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const hammerCount = 40
+
+func TestConcurrentAddDepKeepsAllEntries(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "hub", Title: "Hub"})
+	var depIDs []string
+	for i := 0; i < hammerCount; i++ {
+		id := fmt.Sprintf("d%02d", i)
+		writeFile(dir, &Ticket{ID: id, Title: id})
+		depIDs = append(depIDs, id)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, hammerCount)
+	for _, depID := range depIDs {
+		wg.Add(1)
+		go func(depID string) {
+			defer wg.Done()
+			if err := AddDep(dir, "hub", depID); err != nil {
+				errs <- err
+			}
+		}(depID)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("AddDep: %v", err)
+	}
+
+	loaded, err := Show(dir, "hub")
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if len(loaded.Deps) != hammerCount {
+		t.Errorf("deps = %d, want %d (lost updates)", len(loaded.Deps), hammerCount)
+	}
+	if len(loaded.History) != hammerCount {
+		t.Errorf("history = %d, want %d", len(loaded.History), hammerCount)
+	}
+}
+
+func TestConcurrentAddLinkKeepsAllEntries(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "hub", Title: "Hub"})
+	var otherIDs []string
+	for i := 0; i < hammerCount; i++ {
+		id := fmt.Sprintf("l%02d", i)
+		writeFile(dir, &Ticket{ID: id, Title: id})
+		otherIDs = append(otherIDs, id)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, hammerCount)
+	for _, otherID := range otherIDs {
+		wg.Add(1)
+		go func(otherID string) {
+			defer wg.Done()
+			if err := AddLink(dir, []string{"hub", otherID}); err != nil {
+				errs <- err
+			}
+		}(otherID)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("AddLink: %v", err)
+	}
+
+	loaded, err := Show(dir, "hub")
+	if err != nil {
+		t.Fatalf("Show: %v", err)
+	}
+	if len(loaded.Links) != hammerCount {
+		t.Errorf("hub links = %d, want %d (lost updates)", len(loaded.Links), hammerCount)
+	}
+
+	// The other side of every link is intact too
+	for _, otherID := range otherIDs {
+		other, _ := Show(dir, otherID)
+		if len(other.Links) != 1 || other.Links[0] != "hub" {
+			t.Errorf("%s links = %v, want [hub]", otherID, other.Links)
+		}
+	}
+}
+
+func TestConcurrentAddGeneratesUniqueIDs(t *testing.T) {
+	dir := tempDir(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, hammerCount)
+	for i := 0; i < hammerCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := Add(dir, &Ticket{Title: fmt.Sprintf("Ticket %d", i)}); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Add: %v", err)
+	}
+
+	all, err := List(dir)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != hammerCount {
+		t.Errorf("tickets = %d, want %d", len(all), hammerCount)
+	}
+}
+
+func TestAtomicWriteLeavesNoTempFiles(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A"})
+	if _, err := SetDescription(dir, "aaa", "New description"); err != nil {
+		t.Fatalf("SetDescription: %v", err)
+	}
+	if _, err := AddNote(dir, "aaa", "A note"); err != nil {
+		t.Fatalf("AddNote: %v", err)
+	}
+
+	entries, err := os.ReadDir(mustDirPath(t, dir))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temporary file left behind: %s", e.Name())
+		}
+	}
+	if len(entries) != 1 {
+		t.Errorf("entries = %d, want 1", len(entries))
+	}
+
+	info, err := os.Stat(filepath.Join(mustDirPath(t, dir), "aaa.md"))
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, want 0644", info.Mode().Perm())
+	}
+}
+
+func TestLockDirMissingTicketsDir(t *testing.T) {
+	dir := tempDir(t)
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		t.Fatalf("lockDir: %v", err)
+	}
+	unlock()
+
+	if _, err := os.Stat(mustDirPath(t, dir)); !os.IsNotExist(err) {
+		t.Error("lockDir should not create the tickets directory")
+	}
+}
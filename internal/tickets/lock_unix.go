@@ -0,0 +1,38 @@
+//go:build unix
+
+package tickets
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockDir takes an exclusive advisory lock on the tickets directory, blocking
+// until it is available. The lock is shared with other processes (a second CLI,
+// the TUI) as well as other goroutines, since each call opens its own descriptor.
+// Returns a function that releases the lock.
+// If the tickets directory doesn't exist there is nothing to protect and
+// the returned function is a no-op.
+func lockDir(dir string) (func(), error) {
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(ticketsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return func() {}, nil
+		}
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
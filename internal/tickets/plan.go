@@ -0,0 +1,152 @@
+package tickets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Plan is a schedule of the open tickets derived from their deps.
+type Plan struct {
+	// Waves groups the tickets that can be worked on in parallel: the first wave
+	// has no unfinished deps, and each later wave only depends on earlier ones.
+	// Each wave is sorted by priority, then ID.
+	Waves [][]*Ticket
+	// CriticalPath is the chain of deps with the most estimated work, from the
+	// first ticket to start to the last one to finish. Without estimates it is
+	// the longest chain.
+	CriticalPath []*Ticket
+	// CriticalEstimate is the summed estimate of the critical path.
+	CriticalEstimate time.Duration
+	// TotalEstimate is the summed estimate of every planned ticket.
+	TotalEstimate time.Duration
+	// Unestimated are the planned tickets without a (valid) estimate.
+	Unestimated []*Ticket
+}
+
+// Order returns the tickets in a topological order: wave by wave.
+func (p *Plan) Order() []*Ticket {
+	var result []*Ticket
+	for _, wave := range p.Waves {
+		result = append(result, wave...)
+	}
+	return result
+}
+
+// CycleError is returned when tickets can't be planned because their deps form cycles.
+type CycleError struct {
+	Cycles [][]string
+}
+
+func (e *CycleError) Error() string {
+	var parts []string
+	for _, cycle := range e.Cycles {
+		parts = append(parts, strings.Join(cycle, " -> ")+" -> "+cycle[0])
+	}
+	return fmt.Sprintf("can't plan: dependency cycles found: %s (see todo dep cycle)", strings.Join(parts, "; "))
+}
+
+// BuildPlan schedules the tickets that are not done. Deps on done or missing
+// tickets don't block. Returns a *CycleError if the deps form cycles.
+func BuildPlan(dir string) (*Plan, error) {
+	ticketMap, err := openTicketMap(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if cycles := findCycles(ticketMap); len(cycles) > 0 {
+		return nil, &CycleError{Cycles: cycles}
+	}
+
+	var ids []string
+	for id := range ticketMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// wave[id] is the length of the longest chain of open deps below a ticket;
+	// finish[id] is the most work on any chain ending with it, and prev[id] the
+	// dep that chain goes through. The graph is acyclic, so memoizing is enough.
+	wave := make(map[string]int)
+	finish := make(map[string]time.Duration)
+	length := make(map[string]int)
+	prev := make(map[string]string)
+	done := make(map[string]bool)
+
+	var visit func(id string)
+	visit = func(id string) {
+		if done[id] {
+			return
+		}
+		t := ticketMap[id]
+		for _, depID := range t.Deps {
+			if _, ok := ticketMap[depID]; !ok {
+				continue
+			}
+			visit(depID)
+			if wave[depID]+1 > wave[id] {
+				wave[id] = wave[depID] + 1
+			}
+			if longerChain(finish[depID], length[depID], finish[prev[id]], length[prev[id]], depID, prev[id]) {
+				prev[id] = depID
+			}
+		}
+		finish[id] = t.EstimateDuration()
+		length[id] = 1
+		if p, ok := prev[id]; ok {
+			finish[id] += finish[p]
+			length[id] += length[p]
+		}
+		done[id] = true
+	}
+
+	plan := &Plan{}
+	for _, id := range ids {
+		visit(id)
+
+		t := ticketMap[id]
+		for len(plan.Waves) <= wave[id] {
+			plan.Waves = append(plan.Waves, nil)
+		}
+		plan.Waves[wave[id]] = append(plan.Waves[wave[id]], t)
+
+		plan.TotalEstimate += t.EstimateDuration()
+		if t.EstimateDuration() == 0 {
+			plan.Unestimated = append(plan.Unestimated, t)
+		}
+	}
+	for _, w := range plan.Waves {
+		SortTickets(w, []SortKey{{Field: "priority"}})
+	}
+
+	// The critical path ends at the ticket with the longest chain
+	var last string
+	for _, id := range ids {
+		if last == "" || longerChain(finish[id], length[id], finish[last], length[last], id, last) {
+			last = id
+		}
+	}
+	for id := last; id != ""; id = prev[id] {
+		plan.CriticalPath = append([]*Ticket{ticketMap[id]}, plan.CriticalPath...)
+	}
+	plan.CriticalEstimate = finish[last]
+
+	return plan, nil
+}
+
+// longerChain reports whether chain a (ending at aID) should be preferred over
+// chain b: more work, then more tickets, then the smaller ID for determinism.
+// An empty bID means there is no chain b yet.
+func longerChain(aWork time.Duration, aLen int, bWork time.Duration, bLen int, aID, bID string) bool {
+	if bID == "" {
+		return true
+	}
+	if aWork != bWork {
+		return aWork > bWork
+	}
+	if aLen != bLen {
+		return aLen > bLen
+	}
+	return aID < bID
+}
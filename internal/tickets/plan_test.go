@@ -0,0 +1,99 @@
+package tickets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waveIDs(p *Plan) string {
+	var waves []string
+	for _, w := range p.Waves {
+		var ids []string
+		for _, t := range w {
+			ids = append(ids, t.ID)
+		}
+		waves = append(waves, strings.Join(ids, ","))
+	}
+	return strings.Join(waves, " | ")
+}
+
+func TestBuildPlan(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Design", Estimate: "1d", Priority: 2})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Build", Estimate: "2d", Deps: []string{"aaa"}, Priority: 2})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Docs", Estimate: "2h", Deps: []string{"aaa"}, Priority: 1})
+	writeFile(dir, &Ticket{ID: "ddd", Title: "Release", Deps: []string{"bbb", "ccc", "zzz"}, Priority: 2})
+	writeFile(dir, &Ticket{ID: "eee", Title: "Done dep", Status: "closed", Priority: 2})
+	writeFile(dir, &Ticket{ID: "fff", Title: "Unblocked", Estimate: "3h", Deps: []string{"eee"}, Priority: 2})
+
+	plan, err := BuildPlan(dir)
+	if err != nil {
+		t.Fatalf("BuildPlan: %v", err)
+	}
+
+	// Done and missing deps don't block; waves are sorted by priority
+	if got := waveIDs(plan); got != "aaa,fff | ccc,bbb | ddd" {
+		t.Errorf("waves = %q", got)
+	}
+
+	var path []string
+	for _, t := range plan.CriticalPath {
+		path = append(path, t.ID)
+	}
+	if got := strings.Join(path, ","); got != "aaa,bbb,ddd" {
+		t.Errorf("critical path = %q", got)
+	}
+	if plan.CriticalEstimate != 24*time.Hour {
+		t.Errorf("critical estimate = %v", plan.CriticalEstimate)
+	}
+	if plan.TotalEstimate != 29*time.Hour || len(plan.Unestimated) != 1 {
+		t.Errorf("total = %v, unestimated = %d", plan.TotalEstimate, len(plan.Unestimated))
+	}
+	if len(plan.Order()) != 5 {
+		t.Errorf("order should have 5 tickets, got %d", len(plan.Order()))
+	}
+}
+
+func TestBuildPlanWithoutEstimates(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "B", Deps: []string{"aaa"}})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "C", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "ddd", Title: "D", Deps: []string{"aaa"}})
+
+	plan, err := BuildPlan(dir)
+	if err != nil {
+		t.Fatalf("BuildPlan: %v", err)
+	}
+
+	var path []string
+	for _, t := range plan.CriticalPath {
+		path = append(path, t.ID)
+	}
+	if got := strings.Join(path, ","); got != "aaa,bbb,ccc" {
+		t.Errorf("without estimates the critical path should be the longest chain, got %q", got)
+	}
+}
+
+func TestBuildPlanCycles(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "A", Deps: []string{"bbb"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "B", Deps: []string{"aaa"}})
+
+	_, err := BuildPlan(dir)
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("expected a CycleError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "aaa -> bbb -> aaa") {
+		t.Errorf("error should show the cycle: %v", err)
+	}
+}
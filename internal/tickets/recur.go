@@ -0,0 +1,145 @@
+package tickets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// Recurrence is how often a recurring ticket comes back after it is done.
+type Recurrence struct {
+	Years, Months, Days int
+}
+
+// recurEvery matches a custom interval like "every 3d", "every 2w" or "every 6m".
+var recurEvery = regexp.MustCompile(`^every\s+(\d+)\s*(d|w|m|y)$`)
+
+// ParseRecurrence parses a recur field: daily, weekly, monthly, yearly, or
+// "every N" days, weeks, months or years ("every 3d", "every 2w", "every 6m", "every 1y").
+func ParseRecurrence(s string) (Recurrence, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "daily":
+		return Recurrence{Days: 1}, nil
+	case "weekly":
+		return Recurrence{Days: 7}, nil
+	case "monthly":
+		return Recurrence{Months: 1}, nil
+	case "yearly":
+		return Recurrence{Years: 1}, nil
+	}
+
+	if m := recurEvery.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if n > 0 {
+			switch m[2] {
+			case "d":
+				return Recurrence{Days: n}, nil
+			case "w":
+				return Recurrence{Days: 7 * n}, nil
+			case "m":
+				return Recurrence{Months: n}, nil
+			default:
+				return Recurrence{Years: n}, nil
+			}
+		}
+	}
+
+	return Recurrence{}, fmt.Errorf("invalid recurrence %q: expected daily, weekly, monthly, yearly or every N days, weeks, months or years (e.g. every 3d, every 2w)", s)
+}
+
+// After returns the date one recurrence after d. Months and years never spill
+// into the following month: a day the target month doesn't have becomes its last
+// day, and a date on the last day of its month stays on the last day, so a
+// ticket due on Jan 31 comes back on Feb 28 and then on Mar 31.
+func (r Recurrence) After(d time.Time) time.Time {
+	if r.Years == 0 && r.Months == 0 {
+		return d.AddDate(0, 0, r.Days)
+	}
+
+	year, month, day := d.Date()
+	first := time.Date(year+r.Years, month+time.Month(r.Months), 1, d.Hour(), d.Minute(), d.Second(), d.Nanosecond(), d.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last || d.AddDate(0, 0, 1).Day() == 1 {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1+r.Days)
+}
+
+// occurrenceOnClose returns the next occurrence of t when a status change from
+// oldStatus closes it, or nil when t doesn't recur or was already done. Done,
+// SetStatus and Set all close tickets through it, so every way of closing a
+// recurring ticket brings it back. The successor has no ID yet; see linkOccurrence.
+func occurrenceOnClose(t *Ticket, oldStatus string, cfg *config.Config) (*Ticket, error) {
+	if cfg.IsDone(oldStatus) || !cfg.IsDone(t.Status) {
+		return nil, nil
+	}
+	return nextOccurrence(t, time.Now())
+}
+
+// linkOccurrence gives a successor its ID and links it with the ticket it follows.
+// The closed ticket must be written before the successor, so a failed write never
+// leaves a successor that nothing links to.
+func linkOccurrence(dir, actor string, t, next *Ticket) error {
+	id, err := newTicketID(dir)
+	if err != nil {
+		return err
+	}
+	next.ID = id
+	next.Created = currentTimestamp()
+	next.Links = []string{t.ID}
+
+	t.Links = append(t.Links, next.ID)
+	recordChange(actor, t, "links", "", next.ID)
+	return nil
+}
+
+// nextOccurrence returns a successor for a recurring ticket that is being
+// closed, or nil if the ticket doesn't recur. The successor copies the
+// ticket's title, description (without its notes), type, priority, assignee, tags, parent and
+// planning fields. Its due date is one recurrence after the current one,
+// skipping ahead until it is after today; a ticket without a due date is due
+// one recurrence from today. The scheduled date moves by the same amount.
+func nextOccurrence(t *Ticket, now time.Time) (*Ticket, error) {
+	if t.Recur == "" {
+		return nil, nil
+	}
+	r, err := ParseRecurrence(t.Recur)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	base := today
+	if d, ok := t.DueDate(); ok {
+		base = d
+	}
+	due := r.After(base)
+	for !due.After(today) {
+		due = r.After(due)
+	}
+
+	description, _ := splitNotes(t.Description)
+	next := &Ticket{
+		Title:       t.Title,
+		Description: description,
+		Type:        t.Type,
+		Priority:    t.Priority,
+		Assignee:    t.Assignee,
+		Parent:      t.Parent,
+		Design:      t.Design,
+		Acceptance:  t.Acceptance,
+		Estimate:    t.Estimate,
+		Due:         due.Format(DateLayout),
+		Recur:       t.Recur,
+		Tags:        append([]string(nil), t.Tags...),
+	}
+	if s, err := time.Parse(DateLayout, t.Scheduled); err == nil {
+		next.Scheduled = s.Add(due.Sub(base)).Format(DateLayout)
+	}
+	return next, nil
+}
@@ -0,0 +1,202 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Recurrence
+	}{
+		{"daily", Recurrence{Days: 1}},
+		{"Weekly", Recurrence{Days: 7}},
+		{"monthly", Recurrence{Months: 1}},
+		{"yearly", Recurrence{Years: 1}},
+		{"every 3d", Recurrence{Days: 3}},
+		{"every 2w", Recurrence{Days: 14}},
+		{"every 6m", Recurrence{Months: 6}},
+		{"every 1y", Recurrence{Years: 1}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRecurrence(tt.input)
+		if err != nil {
+			t.Errorf("ParseRecurrence(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRecurrence(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "fortnightly", "every 0d", "every 3", "every 3h"} {
+		if _, err := ParseRecurrence(input); err == nil {
+			t.Errorf("ParseRecurrence(%q) should fail", input)
+		}
+	}
+}
+
+func TestRecurrenceAfterMonthEnd(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse(DateLayout, s)
+		return d
+	}
+
+	tests := []struct {
+		recur string
+		from  string
+		want  []string
+	}{
+		{"monthly", "2026-01-31", []string{"2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"}},
+		{"monthly", "2027-12-31", []string{"2028-01-31", "2028-02-29", "2028-03-31"}},
+		{"monthly", "2026-01-15", []string{"2026-02-15", "2026-03-15"}},
+		{"every 3m", "2026-11-30", []string{"2027-02-28", "2027-05-31"}},
+		{"yearly", "2028-02-29", []string{"2029-02-28", "2030-02-28"}},
+		{"weekly", "2026-01-31", []string{"2026-02-07"}},
+	}
+	for _, tt := range tests {
+		r, err := ParseRecurrence(tt.recur)
+		if err != nil {
+			t.Fatalf("ParseRecurrence(%q): %v", tt.recur, err)
+		}
+		d := date(tt.from)
+		for _, want := range tt.want {
+			d = r.After(d)
+			if got := d.Format(DateLayout); got != want {
+				t.Errorf("%s from %s: got %s, want %s", tt.recur, tt.from, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		ticket        Ticket
+		wantDue       string
+		wantScheduled string
+	}{
+		{"from due date", Ticket{Recur: "weekly", Due: "2026-10-14"}, "2026-10-21", ""},
+		{"skips missed occurrences", Ticket{Recur: "weekly", Due: "2026-09-20"}, "2026-10-18", ""},
+		{"without due date", Ticket{Recur: "every 3d"}, "2026-10-17", ""},
+		{"moves scheduled", Ticket{Recur: "monthly", Due: "2026-10-10", Scheduled: "2026-10-03"}, "2026-11-10", "2026-11-03"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := nextOccurrence(&tt.ticket, now)
+			if err != nil {
+				t.Fatalf("nextOccurrence: %v", err)
+			}
+			if next.Due != tt.wantDue || next.Scheduled != tt.wantScheduled {
+				t.Errorf("due %q scheduled %q, want %q %q", next.Due, next.Scheduled, tt.wantDue, tt.wantScheduled)
+			}
+		})
+	}
+
+	if next, err := nextOccurrence(&Ticket{}, now); next != nil || err != nil {
+		t.Errorf("a ticket without recur should have no next occurrence, got %v, %v", next, err)
+	}
+}
+
+func TestDoneRecurring(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	writeFile(dir, &Ticket{
+		ID: "aaa", Title: "Rotate certs", Description: "Run the rotation script.\n\n## Notes\n\n**2026-10-01** Rotated the staging cert",
+		Type: "chore", Priority: 1, Assignee: "Alice", Tags: []string{"ops"},
+		Recur: "monthly", Due: "2026-10-10", ExternalRef: "OPS-1",
+	})
+
+	title, next, err := Done(dir, "aaa")
+	if err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+	if title != "Rotate certs" || next == nil {
+		t.Fatalf("Done = %q, %v", title, next)
+	}
+
+	got, err := Show(dir, next.ID)
+	if err != nil {
+		t.Fatalf("successor not written: %v", err)
+	}
+	if got.ID == "aaa" || got.Title != "Rotate certs" || got.Description != "Run the rotation script." ||
+		got.Type != "chore" || got.Priority != 1 || got.Assignee != "Alice" || got.Recur != "monthly" ||
+		len(got.Tags) != 1 || got.Tags[0] != "ops" {
+		t.Errorf("successor = %+v", got)
+	}
+	if got.Status != "" || got.ExternalRef != "" || got.Created == "" {
+		t.Errorf("successor should be a fresh open ticket, got %+v", got)
+	}
+	if len(got.Links) != 1 || got.Links[0] != "aaa" {
+		t.Errorf("successor links = %v", got.Links)
+	}
+
+	closed, _ := Show(dir, "aaa")
+	if closed.Status != "closed" || len(closed.Links) != 1 || closed.Links[0] != got.ID {
+		t.Errorf("closed ticket status %q links %v", closed.Status, closed.Links)
+	}
+
+	// Closing the ticket again doesn't create another successor
+	if _, again, err := Done(dir, "aaa"); err != nil || again != nil {
+		t.Errorf("Done on a closed ticket = %v, %v", again, err)
+	}
+	all, _ := List(dir)
+	if len(all) != 2 {
+		t.Errorf("expected the closed ticket and one successor, got %d tickets", len(all))
+	}
+
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Once"})
+	if _, next, err := Done(dir, "bbb"); err != nil || next != nil {
+		t.Errorf("Done on a non-recurring ticket = %v, %v", next, err)
+	}
+}
+
+func TestSetStatusAndSetCreateNextOccurrence(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Rotate certs", Recur: "weekly"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Water plants", Recur: "daily"})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Backup", Recur: "monthly"})
+
+	_, next, err := SetStatus(dir, "aaa", "closed")
+	if err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if next == nil || next.Title != "Rotate certs" || len(next.Links) != 1 || next.Links[0] != "aaa" {
+		t.Fatalf("SetStatus successor = %+v", next)
+	}
+	if _, err := Show(dir, next.ID); err != nil {
+		t.Errorf("successor not written: %v", err)
+	}
+
+	// Closing several tickets at once, as `todo bulk close` does, creates one successor each
+	updated, created, err := Set(dir, []string{"bbb", "ccc"}, mustParseChanges(t, "status=closed"))
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if len(updated) != 2 || len(created) != 2 || created[0].ID == created[1].ID {
+		t.Fatalf("Set updated %d tickets and created %v", len(updated), created)
+	}
+	for i, id := range []string{"bbb", "ccc"} {
+		closed, _ := Show(dir, id)
+		if len(closed.Links) != 1 || closed.Links[0] != created[i].ID {
+			t.Errorf("%s links = %v, want %s", id, closed.Links, created[i].ID)
+		}
+	}
+
+	// Other status changes, and closing an already closed ticket, don't recur
+	if _, next, err := SetStatus(dir, "aaa", "closed"); err != nil || next != nil {
+		t.Errorf("SetStatus on a closed ticket = %v, %v", next, err)
+	}
+	if _, created, err := Set(dir, []string{next.ID}, mustParseChanges(t, "status=in_progress")); err != nil || len(created) != 0 {
+		t.Errorf("Set in_progress created %v, %v", created, err)
+	}
+	all, _ := List(dir)
+	if len(all) != 6 {
+		t.Errorf("expected three closed tickets and three successors, got %d tickets", len(all))
+	}
+}
@@ -3,15 +3,17 @@ package tickets
 import (
 	"fmt"
 	"strings"
+
+	"github.com/juanibiapina/todo/internal/config"
 )
 
 // TicketRelations holds computed relationships for a ticket.
 type TicketRelations struct {
 	// ParentTicket is the resolved parent ticket (nil if no parent or not found).
 	ParentTicket *Ticket
-	// Blockers are unclosed tickets this ticket depends on.
+	// Blockers are tickets this ticket depends on that are not done.
 	Blockers []*Ticket
-	// Blocking are tickets that depend on this ticket (and this ticket is unclosed).
+	// Blocking are tickets that depend on this ticket (and this ticket is not done).
 	Blocking []*Ticket
 	// Children are tickets whose parent is this ticket.
 	Children []*Ticket
@@ -20,8 +22,8 @@ type TicketRelations struct {
 }
 
 // ComputeRelations computes all relationships for a given ticket
-// using the full list of all tickets.
-func ComputeRelations(ticket *Ticket, allTickets []*Ticket) *TicketRelations {
+// using the full list of all tickets. The config decides which statuses count as done.
+func ComputeRelations(ticket *Ticket, allTickets []*Ticket, cfg *config.Config) *TicketRelations {
 	// Build ID -> Ticket map
 	ticketMap := make(map[string]*Ticket)
 	for _, t := range allTickets {
@@ -37,17 +39,17 @@ func ComputeRelations(ticket *Ticket, allTickets []*Ticket) *TicketRelations {
 		}
 	}
 
-	// Blockers: unclosed deps of this ticket
+	// Blockers: deps of this ticket that are not done
 	for _, depID := range ticket.Deps {
 		if dep, ok := ticketMap[depID]; ok {
-			if dep.Status != "closed" {
+			if !cfg.IsDone(dep.Status) {
 				rel.Blockers = append(rel.Blockers, dep)
 			}
 		}
 	}
 
-	// Blocking: tickets that have this ticket as a dep, where this ticket is unclosed
-	if ticket.Status != "closed" {
+	// Blocking: tickets that have this ticket as a dep, where this ticket is not done
+	if !cfg.IsDone(ticket.Status) {
 		for _, t := range allTickets {
 			if t.ID == ticket.ID {
 				continue
@@ -3,13 +3,15 @@ package tickets
 import (
 	"strings"
 	"testing"
+
+	"github.com/juanibiapina/todo/internal/config"
 )
 
 func TestComputeRelations_NoRelations(t *testing.T) {
 	ticket := &Ticket{ID: "aaa", Title: "Standalone"}
 	all := []*Ticket{ticket}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	if rel.ParentTicket != nil {
 		t.Errorf("expected nil parent, got %v", rel.ParentTicket)
@@ -33,7 +35,7 @@ func TestComputeRelations_Parent(t *testing.T) {
 	child := &Ticket{ID: "chi", Title: "Child Ticket", Parent: "par"}
 	all := []*Ticket{parent, child}
 
-	rel := ComputeRelations(child, all)
+	rel := ComputeRelations(child, all, config.Default())
 
 	if rel.ParentTicket == nil {
 		t.Fatal("expected parent ticket, got nil")
@@ -50,7 +52,7 @@ func TestComputeRelations_ParentNotFound(t *testing.T) {
 	ticket := &Ticket{ID: "aaa", Title: "Orphan", Parent: "zzz"}
 	all := []*Ticket{ticket}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	if rel.ParentTicket != nil {
 		t.Errorf("expected nil parent for missing parent ID, got %v", rel.ParentTicket)
@@ -64,7 +66,7 @@ func TestComputeRelations_Blockers(t *testing.T) {
 	ticket := &Ticket{ID: "aaa", Title: "Main", Deps: []string{"d1a", "d2a", "d3a"}}
 	all := []*Ticket{dep1, dep2, dep3, ticket}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	if len(rel.Blockers) != 2 {
 		t.Fatalf("expected 2 blockers (open + in_progress), got %d", len(rel.Blockers))
@@ -81,7 +83,7 @@ func TestComputeRelations_BlockersMissingDep(t *testing.T) {
 	ticket := &Ticket{ID: "aaa", Title: "Main", Deps: []string{"zzz"}}
 	all := []*Ticket{ticket}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	// Missing deps are not listed as blockers
 	if len(rel.Blockers) != 0 {
@@ -96,7 +98,7 @@ func TestComputeRelations_Blocking(t *testing.T) {
 	unrelated := &Ticket{ID: "uuu", Title: "Unrelated"}
 	all := []*Ticket{ticket, blocked1, blocked2, unrelated}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	if len(rel.Blocking) != 2 {
 		t.Fatalf("expected 2 blocking, got %d", len(rel.Blocking))
@@ -108,7 +110,7 @@ func TestComputeRelations_BlockingNotShownWhenClosed(t *testing.T) {
 	blocked := &Ticket{ID: "bbb", Title: "Depends on Done", Deps: []string{"aaa"}}
 	all := []*Ticket{ticket, blocked}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	// When the ticket is closed, it doesn't block anyone
 	if len(rel.Blocking) != 0 {
@@ -116,6 +118,29 @@ func TestComputeRelations_BlockingNotShownWhenClosed(t *testing.T) {
 	}
 }
 
+func TestComputeRelations_CustomDoneStatuses(t *testing.T) {
+	cfg := config.Default()
+	cfg.Statuses = []string{"open", "review", "closed", "wontfix"}
+	cfg.DoneStatuses = []string{"closed", "wontfix"}
+
+	dep1 := &Ticket{ID: "d1a", Title: "Abandoned Dep", Status: "wontfix"}
+	dep2 := &Ticket{ID: "d2a", Title: "Review Dep", Status: "review"}
+	ticket := &Ticket{ID: "aaa", Title: "Main", Deps: []string{"d1a", "d2a"}}
+	all := []*Ticket{dep1, dep2, ticket}
+
+	rel := ComputeRelations(ticket, all, cfg)
+
+	if len(rel.Blockers) != 1 || rel.Blockers[0].ID != "d2a" {
+		t.Fatalf("expected only 'd2a' as blocker, got %v", rel.Blockers)
+	}
+
+	// A ticket in a done status doesn't block anyone
+	rel = ComputeRelations(dep1, all, cfg)
+	if len(rel.Blocking) != 0 {
+		t.Errorf("expected 0 blocking for wontfix ticket, got %d", len(rel.Blocking))
+	}
+}
+
 func TestComputeRelations_Children(t *testing.T) {
 	parent := &Ticket{ID: "par", Title: "Epic"}
 	child1 := &Ticket{ID: "c1a", Title: "Task 1", Parent: "par"}
@@ -123,7 +148,7 @@ func TestComputeRelations_Children(t *testing.T) {
 	other := &Ticket{ID: "ooo", Title: "Other", Parent: "xxx"}
 	all := []*Ticket{parent, child1, child2, other}
 
-	rel := ComputeRelations(parent, all)
+	rel := ComputeRelations(parent, all, config.Default())
 
 	if len(rel.Children) != 2 {
 		t.Fatalf("expected 2 children, got %d", len(rel.Children))
@@ -142,7 +167,7 @@ func TestComputeRelations_Linked(t *testing.T) {
 	link2 := &Ticket{ID: "ccc", Title: "Linked 2"}
 	all := []*Ticket{ticket, link1, link2}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	if len(rel.Linked) != 2 {
 		t.Fatalf("expected 2 linked, got %d", len(rel.Linked))
@@ -156,7 +181,7 @@ func TestComputeRelations_LinkedMissing(t *testing.T) {
 	ticket := &Ticket{ID: "aaa", Title: "Main", Links: []string{"zzz"}}
 	all := []*Ticket{ticket}
 
-	rel := ComputeRelations(ticket, all)
+	rel := ComputeRelations(ticket, all, config.Default())
 
 	// Missing links are not listed
 	if len(rel.Linked) != 0 {
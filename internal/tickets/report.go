@@ -0,0 +1,118 @@
+package tickets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeReportRow is the time tracked for one group of a time report.
+type TimeReportRow struct {
+	Key     string // assignee, tag or parent ID; empty for tickets without one
+	Label   string // Key, or "ID Title" for parents
+	Spent   time.Duration
+	Tickets int
+}
+
+// timeReportGroups lists the groupings TimeReport accepts.
+var timeReportGroups = []string{"assignee", "tag", "parent"}
+
+// TimeReport sums the work sessions of the tickets between since and until
+// (zero means unbounded), grouped by assignee, tag or parent. Sessions are
+// clipped to the range and running ones count until now. Without a range, spent
+// time entered by hand (the part of spent that finished sessions don't account
+// for) counts too; it has no date, so a range leaves it out. A ticket with several
+// tags counts in each of them, so the returned total (each ticket counted once) can
+// be less than the sum of the rows. Rows are sorted by time, most first, then key.
+func TimeReport(items, allTickets []*Ticket, by string, since, until time.Time) ([]TimeReportRow, time.Duration, error) {
+	if !containsString(timeReportGroups, by) {
+		return nil, 0, fmt.Errorf("invalid grouping %q: must be one of %s", by, strings.Join(timeReportGroups, ", "))
+	}
+
+	titles := make(map[string]string)
+	for _, t := range allTickets {
+		titles[t.ID] = t.Title
+	}
+
+	now := time.Now()
+	unbounded := since.IsZero() && until.IsZero()
+	var total time.Duration
+	rows := make(map[string]*TimeReportRow)
+	for _, t := range items {
+		var spent, finished time.Duration
+		for _, s := range t.Sessions {
+			start, end, ok := s.bounds(now)
+			if !ok {
+				continue
+			}
+			if s.End != "" {
+				finished += end.Sub(start)
+			}
+			if !since.IsZero() && start.Before(since) {
+				start = since
+			}
+			if !until.IsZero() && end.After(until) {
+				end = until
+			}
+			if end.After(start) {
+				spent += end.Sub(start)
+			}
+		}
+		if manual := t.SpentDuration() - finished; unbounded && manual > 0 {
+			spent += manual
+		}
+		if spent == 0 {
+			continue
+		}
+		total += spent
+
+		var keys []string
+		switch by {
+		case "assignee":
+			keys = []string{t.Assignee}
+		case "tag":
+			keys = t.Tags
+			if len(keys) == 0 {
+				keys = []string{""}
+			}
+		case "parent":
+			keys = []string{t.Parent}
+		}
+
+		for _, key := range keys {
+			row, ok := rows[key]
+			if !ok {
+				row = &TimeReportRow{Key: key, Label: key}
+				if by == "parent" && key != "" {
+					row.Label = strings.TrimSpace(key + " " + titles[key])
+				}
+				rows[key] = row
+			}
+			row.Spent += spent
+			row.Tickets++
+		}
+	}
+
+	var result []TimeReportRow
+	for _, row := range rows {
+		result = append(result, *row)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Spent != result[j].Spent {
+			return result[i].Spent > result[j].Spent
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result, total, nil
+}
+
+// ParseDateRange parses a date (YYYY-MM-DD, a whole UTC day) or an RFC3339 time and
+// returns its start and exclusive end, like dates in filter expressions.
+func ParseDateRange(value string) (time.Time, time.Time, error) {
+	d, err := parseFilterDate(value)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q: %w", value, err)
+	}
+	return d.start, d.end, nil
+}
@@ -0,0 +1,100 @@
+package tickets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeReport(t *testing.T) {
+	session := func(start, end string) WorkSession {
+		return WorkSession{Start: start, End: end}
+	}
+	parent := &Ticket{ID: "ppp", Title: "Epic"}
+	items := []*Ticket{
+		{ID: "aaa", Assignee: "Alice", Parent: "ppp", Tags: []string{"ui", "auth"}, Sessions: []WorkSession{
+			session("2026-09-30T22:00:00Z", "2026-10-01T02:00:00Z"), // clipped to 2h
+			session("2026-10-02T09:00:00Z", "2026-10-02T10:00:00Z"),
+		}},
+		{ID: "bbb", Assignee: "Bob", Tags: []string{"ui"}, Sessions: []WorkSession{
+			session("2026-10-03T09:00:00Z", "2026-10-03T13:00:00Z"),
+		}},
+		{ID: "ccc", Sessions: []WorkSession{
+			session("2026-10-04T09:00:00Z", "2026-10-04T09:30:00Z"),
+		}},
+		{ID: "ddd", Assignee: "Alice", Sessions: []WorkSession{
+			session("2026-09-01T09:00:00Z", "2026-09-01T10:00:00Z"), // before --since
+		}},
+	}
+	all := append([]*Ticket{parent}, items...)
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		by   string
+		want []TimeReportRow
+	}{
+		{"assignee", []TimeReportRow{
+			{Key: "Bob", Label: "Bob", Spent: 4 * time.Hour, Tickets: 1},
+			{Key: "Alice", Label: "Alice", Spent: 3 * time.Hour, Tickets: 1},
+			{Key: "", Label: "", Spent: 30 * time.Minute, Tickets: 1},
+		}},
+		{"tag", []TimeReportRow{
+			{Key: "ui", Label: "ui", Spent: 7 * time.Hour, Tickets: 2},
+			{Key: "auth", Label: "auth", Spent: 3 * time.Hour, Tickets: 1},
+			{Key: "", Label: "", Spent: 30 * time.Minute, Tickets: 1},
+		}},
+		{"parent", []TimeReportRow{
+			{Key: "", Label: "", Spent: 4*time.Hour + 30*time.Minute, Tickets: 2},
+			{Key: "ppp", Label: "ppp Epic", Spent: 3 * time.Hour, Tickets: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			rows, total, err := TimeReport(items, all, tt.by, since, time.Time{})
+			if err != nil {
+				t.Fatalf("TimeReport: %v", err)
+			}
+			if total != 7*time.Hour+30*time.Minute {
+				t.Errorf("total = %v", total)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("rows = %+v, want %+v", rows, tt.want)
+			}
+			for i := range rows {
+				if rows[i] != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, rows[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, _, err := TimeReport(items, all, "status", since, time.Time{}); err == nil {
+		t.Error("expected an error for an invalid grouping")
+	}
+}
+
+func TestTimeReportManualSpent(t *testing.T) {
+	items := []*Ticket{
+		// 1h tracked, plus 2h entered by hand
+		{ID: "aaa", Assignee: "Alice", Spent: "3h", Sessions: []WorkSession{
+			{Start: "2026-10-02T09:00:00Z", End: "2026-10-02T10:00:00Z"},
+		}},
+		{ID: "bbb", Assignee: "Bob", Spent: "30m"},
+	}
+
+	rows, total, err := TimeReport(items, items, "assignee", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("TimeReport: %v", err)
+	}
+	if total != 3*time.Hour+30*time.Minute || len(rows) != 2 || rows[0].Spent != 3*time.Hour || rows[1].Spent != 30*time.Minute {
+		t.Errorf("without a range, manual spent time should count: total %v, rows %+v", total, rows)
+	}
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows, total, err = TimeReport(items, items, "assignee", since, time.Time{})
+	if err != nil {
+		t.Fatalf("TimeReport: %v", err)
+	}
+	if total != time.Hour || len(rows) != 1 {
+		t.Errorf("with a range, only sessions should count: total %v, rows %+v", total, rows)
+	}
+}
@@ -0,0 +1,219 @@
+package tickets
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Searchable fields, in the order snippets are chosen from.
+const (
+	SearchFieldTitle       = "title"
+	SearchFieldDescription = "description"
+	SearchFieldDesign      = "design"
+	SearchFieldAcceptance  = "acceptance"
+	SearchFieldNotes       = "notes"
+)
+
+// searchWeights ranks a hit in the title above a hit in the body.
+var searchWeights = map[string]int{
+	SearchFieldTitle:       10,
+	SearchFieldDescription: 3,
+	SearchFieldDesign:      2,
+	SearchFieldAcceptance:  2,
+	SearchFieldNotes:       1,
+}
+
+// snippetContext is the number of bytes shown before and after a match.
+const snippetContext = 40
+
+// Span is a byte range [Start, End) within a string.
+type Span struct {
+	Start int
+	End   int
+}
+
+// SearchResult is a ticket matching a search, with the matches in its title and a
+// snippet of the best body match (empty if only the title matched). Title is the
+// ticket's title with whitespace collapsed, which TitleHighlights refer to.
+type SearchResult struct {
+	Ticket          *Ticket
+	Score           int
+	Title           string
+	TitleHighlights []Span
+
+	SnippetField      string
+	Snippet           string
+	SnippetHighlights []Span
+}
+
+// Search finds the tickets containing every term in their title, description,
+// design, acceptance criteria or notes, ranked by relevance: title hits count more
+// than body hits. Terms are case-insensitive literals, or regular expressions if
+// regex is set. Ties are broken by priority, then ID.
+func Search(items []*Ticket, terms []string, regex bool) ([]SearchResult, error) {
+	var patterns []*regexp.Regexp
+	for _, term := range terms {
+		expr := term
+		if !regex {
+			expr = regexp.QuoteMeta(term)
+		}
+		re, err := regexp.Compile("(?i)" + expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search pattern %q: %w", term, err)
+		}
+		patterns = append(patterns, re)
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no search terms given")
+	}
+
+	var results []SearchResult
+	for _, t := range items {
+		if r, ok := searchTicket(t, patterns); ok {
+			results = append(results, r)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Ticket.Priority != b.Ticket.Priority {
+			return a.Ticket.Priority < b.Ticket.Priority
+		}
+		return a.Ticket.ID < b.Ticket.ID
+	})
+
+	return results, nil
+}
+
+// searchFields returns the searchable text of a ticket by field. Notes are the part
+// of the description from the "## Notes" heading on.
+func searchFields(t *Ticket) [][2]string {
+	description, notes := splitNotes(t.Description)
+
+	return [][2]string{
+		{SearchFieldTitle, t.Title},
+		{SearchFieldDescription, description},
+		{SearchFieldDesign, t.Design},
+		{SearchFieldAcceptance, t.Acceptance},
+		{SearchFieldNotes, notes},
+	}
+}
+
+// searchTicket scores a ticket. Every pattern must match at least one field.
+func searchTicket(t *Ticket, patterns []*regexp.Regexp) (SearchResult, bool) {
+	result := SearchResult{Ticket: t, Title: strings.Join(strings.Fields(t.Title), " ")}
+	matched := make([]bool, len(patterns))
+	bestWeight := 0
+
+	for _, field := range searchFields(t) {
+		name := field[0]
+		// Collapse whitespace so snippets stay on one line
+		text := strings.Join(strings.Fields(field[1]), " ")
+
+		spans := findSpans(text, patterns, matched)
+		if len(spans) == 0 {
+			continue
+		}
+		result.Score += len(spans) * searchWeights[name]
+
+		if name == SearchFieldTitle {
+			result.TitleHighlights = spans
+		} else if searchWeights[name] > bestWeight {
+			bestWeight = searchWeights[name]
+			result.SnippetField = name
+			result.Snippet, result.SnippetHighlights = snippet(text, spans)
+		}
+	}
+
+	for _, ok := range matched {
+		if !ok {
+			return SearchResult{}, false
+		}
+	}
+	return result, true
+}
+
+// findSpans returns the sorted, non-overlapping matches of all patterns in text and
+// marks the patterns that matched. Empty matches are ignored.
+func findSpans(text string, patterns []*regexp.Regexp, matched []bool) []Span {
+	var spans []Span
+	for i, re := range patterns {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			spans = append(spans, Span{Start: loc[0], End: loc[1]})
+			matched[i] = true
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].Start < spans[j].Start })
+
+	// Merge overlapping matches from different patterns
+	var merged []Span
+	for _, s := range spans {
+		if n := len(merged); n > 0 && s.Start <= merged[n-1].End {
+			if s.End > merged[n-1].End {
+				merged[n-1].End = s.End
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// snippet cuts the text around the first match, with "…" where text was cut, and
+// returns the highlights that fall inside it, relative to the snippet.
+func snippet(text string, spans []Span) (string, []Span) {
+	first := spans[0]
+
+	start := first.Start - snippetContext
+	if start <= 0 {
+		start = 0
+	} else {
+		// Start at a word boundary if there is one close by
+		if i := strings.IndexByte(text[start:first.Start], ' '); i >= 0 {
+			start += i + 1
+		}
+		for start < first.Start && !utf8.RuneStart(text[start]) {
+			start++
+		}
+	}
+
+	end := first.End + snippetContext
+	if end >= len(text) {
+		end = len(text)
+	} else {
+		if i := strings.LastIndexByte(text[first.End:end], ' '); i >= 0 {
+			end = first.End + i
+		}
+		for end > first.End && !utf8.RuneStart(text[end]) {
+			end--
+		}
+	}
+
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = "…"
+	}
+	if end < len(text) {
+		suffix = "…"
+	}
+
+	var highlights []Span
+	for _, s := range spans {
+		if s.Start >= start && s.End <= end {
+			offset := len(prefix) - start
+			highlights = append(highlights, Span{Start: s.Start + offset, End: s.End + offset})
+		}
+	}
+
+	return prefix + text[start:end] + suffix, highlights
+}
@@ -0,0 +1,129 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+)
+
+func resultIDs(results []SearchResult) []string {
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Ticket.ID)
+	}
+	return ids
+}
+
+func TestSearchRanksTitleAboveBody(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", Title: "Refactor auth", Description: "Login is slow"},
+		{ID: "bbb", Title: "Fix login timeout"},
+		{ID: "ccc", Title: "Write docs"},
+		{ID: "ddd", Title: "Cleanup", Description: "Old notes\n\n## Notes\n\n**2026-01-01 10:00 UTC**\n\nlogin again"},
+	}
+
+	results, err := Search(items, []string{"LOGIN"}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	got := strings.Join(resultIDs(results), " ")
+	if got != "bbb aaa ddd" {
+		t.Errorf("results = %q, want %q", got, "bbb aaa ddd")
+	}
+
+	if len(results[0].TitleHighlights) != 1 || results[0].TitleHighlights[0] != (Span{4, 9}) {
+		t.Errorf("title highlights = %v", results[0].TitleHighlights)
+	}
+	if results[0].Snippet != "" {
+		t.Errorf("title-only match should have no snippet, got %q", results[0].Snippet)
+	}
+	if results[1].SnippetField != SearchFieldDescription || results[1].Snippet != "Login is slow" {
+		t.Errorf("snippet = %s %q", results[1].SnippetField, results[1].Snippet)
+	}
+	if results[2].SnippetField != SearchFieldNotes {
+		t.Errorf("snippet field = %q, want notes", results[2].SnippetField)
+	}
+}
+
+func TestSearchAllTermsMustMatch(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", Title: "Login timeout", Design: "Use a shorter session"},
+		{ID: "bbb", Title: "Login page"},
+	}
+
+	results, err := Search(items, []string{"login", "session"}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got := resultIDs(results); len(got) != 1 || got[0] != "aaa" {
+		t.Errorf("results = %v, want [aaa]", got)
+	}
+	if results[0].SnippetField != SearchFieldDesign {
+		t.Errorf("snippet field = %q, want design", results[0].SnippetField)
+	}
+}
+
+func TestSearchRegex(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", Title: "Login time out"},
+		{ID: "bbb", Title: "Login timeout"},
+		{ID: "ccc", Title: "Logout"},
+	}
+
+	results, err := Search(items, []string{"time ?out"}, true)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got := strings.Join(resultIDs(results), " "); got != "aaa bbb" {
+		t.Errorf("results = %q, want %q", got, "aaa bbb")
+	}
+
+	// Without regex, the pattern is a literal
+	results, _ = Search(items, []string{"time ?out"}, false)
+	if len(results) != 0 {
+		t.Errorf("literal search matched %v", resultIDs(results))
+	}
+
+	if _, err := Search(items, []string{"("}, true); err == nil || !strings.Contains(err.Error(), "invalid search pattern") {
+		t.Errorf("expected invalid pattern error, got %v", err)
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	long := strings.Repeat("lorem ipsum ", 10) + "the needle is here " + strings.Repeat("dolor sit ", 10)
+	items := []*Ticket{{ID: "aaa", Title: "Haystack", Description: long}}
+
+	results, err := Search(items, []string{"needle"}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	r := results[0]
+	if !strings.HasPrefix(r.Snippet, "…") || !strings.HasSuffix(r.Snippet, "…") {
+		t.Errorf("snippet should be cut on both sides: %q", r.Snippet)
+	}
+	if len(r.SnippetHighlights) != 1 {
+		t.Fatalf("highlights = %v", r.SnippetHighlights)
+	}
+	h := r.SnippetHighlights[0]
+	if r.Snippet[h.Start:h.End] != "needle" {
+		t.Errorf("highlight covers %q, want needle", r.Snippet[h.Start:h.End])
+	}
+	if strings.Contains(r.Snippet, "lorem ipsum lorem ipsum lorem ipsum lorem") {
+		t.Errorf("snippet too long: %q", r.Snippet)
+	}
+}
+
+func TestSearchTitleHighlightsMatchTitle(t *testing.T) {
+	items := []*Ticket{{ID: "aaa", Title: "Fix  the\tlogin page"}}
+
+	results, err := Search(items, []string{"login"}, false)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	r := results[0]
+	if r.Title != "Fix the login page" {
+		t.Errorf("title = %q", r.Title)
+	}
+	if s := r.TitleHighlights[0]; r.Title[s.Start:s.End] != "login" {
+		t.Errorf("highlight covers %q, want %q", r.Title[s.Start:s.End], "login")
+	}
+}
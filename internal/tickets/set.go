@@ -0,0 +1,320 @@
+package tickets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+)
+
+// Assignment operators for FieldChange.
+const (
+	OpSet    = "="
+	OpAdd    = "+="
+	OpRemove = "-="
+)
+
+// FieldChange is a single change to a ticket field, written as "field=value",
+// "field+=value" or "field-=value". Adding and removing only apply to tags.
+type FieldChange struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// settableFields lists the fields that can be changed with Set.
+var settableFields = []string{
+	"title", "status", "type", "priority", "assignee", "parent",
+	"external_ref", "design", "acceptance", "estimate", "spent", "due", "scheduled", "recur", "tags",
+}
+
+// ParseFieldChange parses an assignment like "priority=1", "tags+=perf" or "tags-=ui".
+func ParseFieldChange(s string) (FieldChange, error) {
+	eq := strings.Index(s, "=")
+	if eq <= 0 {
+		return FieldChange{}, fmt.Errorf("invalid assignment %q: expected field=value", s)
+	}
+
+	c := FieldChange{Field: s[:eq], Op: OpSet, Value: s[eq+1:]}
+	switch {
+	case strings.HasSuffix(c.Field, "+"):
+		c.Field, c.Op = strings.TrimSuffix(c.Field, "+"), OpAdd
+	case strings.HasSuffix(c.Field, "-"):
+		c.Field, c.Op = strings.TrimSuffix(c.Field, "-"), OpRemove
+	}
+
+	if !isSettable(c.Field) {
+		return FieldChange{}, fmt.Errorf("unknown field %q: must be one of %s", c.Field, strings.Join(settableFields, ", "))
+	}
+	if c.Op != OpSet && c.Field != "tags" {
+		return FieldChange{}, fmt.Errorf("invalid assignment %q: %s only applies to tags", s, c.Op)
+	}
+
+	return c, nil
+}
+
+func isSettable(field string) bool {
+	for _, f := range settableFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Set applies field changes to every ticket with the given IDs (partial IDs allowed)
+// and returns the tickets that changed; tickets the changes leave as they are aren't
+// rewritten. Values are validated like `todo add`: type, status and priority against
+// the config, status changes against the allowed transitions, and parents must
+// exist. Nothing is written unless every change is valid. Like Done, closing a
+// recurring ticket creates its next occurrence; these are returned second.
+func Set(dir string, ids []string, changes []FieldChange) ([]*Ticket, []*Ticket, error) {
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	type target struct {
+		path     string
+		ticket   *Ticket
+		original string
+		next     *Ticket
+	}
+	var targets []target
+	seen := make(map[string]bool)
+
+	for _, id := range ids {
+		path, err := findTicketFile(dir, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		t, err := parseFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		targets = append(targets, target{path: path, ticket: t, original: t.FullString()})
+	}
+
+	// Apply all changes in memory first so an invalid one leaves every file untouched
+	for i := range targets {
+		tg := &targets[i]
+		oldStatus := tg.ticket.Status
+		for _, c := range changes {
+			if err := applyFieldChange(dir, actor, tg.ticket, fileID(tg.path), c, cfg); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", fileID(tg.path), err)
+			}
+		}
+		if tg.next, err = occurrenceOnClose(tg.ticket, oldStatus, cfg); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", fileID(tg.path), err)
+		}
+	}
+
+	var updated, created []*Ticket
+	for _, tg := range targets {
+		// Successors get their IDs one at a time, so each sees the ones written before it
+		if tg.next != nil {
+			if err := linkOccurrence(dir, actor, tg.ticket, tg.next); err != nil {
+				return nil, nil, err
+			}
+		}
+		content := tg.ticket.FullString()
+		if content == tg.original {
+			continue
+		}
+		if err := atomicWriteFile(tg.path, []byte(content)); err != nil {
+			return nil, nil, err
+		}
+		updated = append(updated, tg.ticket)
+		if tg.next != nil {
+			if err := writeFile(dir, tg.next); err != nil {
+				return nil, nil, err
+			}
+			created = append(created, tg.next)
+		}
+	}
+
+	return updated, created, nil
+}
+
+// applyFieldChange validates and applies a single change to a ticket, recording it in
+// the ticket's history if the value actually changes.
+func applyFieldChange(dir, actor string, t *Ticket, id string, c FieldChange, cfg *config.Config) error {
+	switch c.Field {
+	case "title":
+		if strings.TrimSpace(c.Value) == "" {
+			return fmt.Errorf("title must not be empty")
+		}
+		setField(actor, t, "title", &t.Title, c.Value)
+
+	case "status":
+		if err := cfg.CheckStatus(c.Value); err != nil {
+			return err
+		}
+		if err := cfg.CheckTransition(t.Status, c.Value); err != nil {
+			return err
+		}
+		setStatus(actor, t, c.Value, cfg)
+
+	case "type":
+		if err := cfg.CheckType(c.Value); err != nil {
+			return err
+		}
+		setField(actor, t, "type", &t.Type, c.Value)
+
+	case "priority":
+		p, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return fmt.Errorf("invalid priority %q: must be a number", c.Value)
+		}
+		if err := cfg.CheckPriority(p); err != nil {
+			return err
+		}
+		if p != t.Priority {
+			recordChange(actor, t, "priority", strconv.Itoa(t.Priority), c.Value)
+			t.Priority = p
+		}
+
+	case "assignee":
+		setField(actor, t, "assignee", &t.Assignee, c.Value)
+
+	case "parent":
+		parent := c.Value
+		if parent != "" {
+			path, err := findTicketFile(dir, parent)
+			if err != nil {
+				return fmt.Errorf("parent ticket not found: %s", parent)
+			}
+			parent = fileID(path)
+			if parent == id {
+				return fmt.Errorf("a ticket cannot be its own parent")
+			}
+		}
+		setField(actor, t, "parent", &t.Parent, parent)
+
+	case "external_ref":
+		setField(actor, t, "external_ref", &t.ExternalRef, c.Value)
+
+	case "design":
+		setTextField(actor, t, "design", &t.Design, c.Value)
+
+	case "acceptance":
+		setTextField(actor, t, "acceptance", &t.Acceptance, c.Value)
+
+	case "estimate":
+		if c.Value != "" {
+			if _, err := ParseEstimate(c.Value); err != nil {
+				return err
+			}
+		}
+		setField(actor, t, "estimate", &t.Estimate, c.Value)
+
+	case "spent":
+		if c.Value != "" {
+			if _, err := ParseEstimate(c.Value); err != nil {
+				return err
+			}
+		}
+		setField(actor, t, "spent", &t.Spent, c.Value)
+
+	case "due", "scheduled":
+		value := c.Value
+		if value != "" {
+			var err error
+			if value, err = ParseDate(value, time.Now()); err != nil {
+				return err
+			}
+		}
+		if c.Field == "due" {
+			setField(actor, t, "due", &t.Due, value)
+		} else {
+			setField(actor, t, "scheduled", &t.Scheduled, value)
+		}
+
+	case "recur":
+		if c.Value != "" {
+			if _, err := ParseRecurrence(c.Value); err != nil {
+				return err
+			}
+		}
+		setField(actor, t, "recur", &t.Recur, c.Value)
+
+	case "tags":
+		applyTagsChange(actor, t, c)
+	}
+
+	return nil
+}
+
+// setField sets a single-line field and records the old and new values.
+func setField(actor string, t *Ticket, field string, dst *string, value string) {
+	if *dst == value {
+		return
+	}
+	recordChange(actor, t, field, *dst, value)
+	*dst = value
+}
+
+// setTextField sets a free-text field. Like descriptions, only the fact that it
+// changed is recorded, not the text itself.
+func setTextField(actor string, t *Ticket, field string, dst *string, value string) {
+	if *dst == value {
+		return
+	}
+	recordChange(actor, t, field, "", "")
+	*dst = value
+}
+
+// applyTagsChange replaces, adds or removes comma-separated tags.
+func applyTagsChange(actor string, t *Ticket, c FieldChange) {
+	var values []string
+	for _, tag := range strings.Split(c.Value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			values = append(values, tag)
+		}
+	}
+
+	switch c.Op {
+	case OpSet:
+		for _, tag := range t.Tags {
+			if !containsString(values, tag) {
+				recordChange(actor, t, "tags", tag, "")
+			}
+		}
+		for _, tag := range values {
+			if !containsString(t.Tags, tag) {
+				recordChange(actor, t, "tags", "", tag)
+			}
+		}
+		t.Tags = values
+	case OpAdd:
+		for _, tag := range values {
+			if !containsString(t.Tags, tag) {
+				t.Tags = append(t.Tags, tag)
+				recordChange(actor, t, "tags", "", tag)
+			}
+		}
+	case OpRemove:
+		for _, tag := range values {
+			if containsString(t.Tags, tag) {
+				t.Tags = removeString(t.Tags, tag)
+				recordChange(actor, t, "tags", tag, "")
+			}
+		}
+	}
+}
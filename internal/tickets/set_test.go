@@ -0,0 +1,199 @@
+package tickets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFieldChange(t *testing.T) {
+	tests := []struct {
+		input string
+		want  FieldChange
+	}{
+		{"priority=1", FieldChange{Field: "priority", Op: OpSet, Value: "1"}},
+		{"title=a=b", FieldChange{Field: "title", Op: OpSet, Value: "a=b"}},
+		{"assignee=", FieldChange{Field: "assignee", Op: OpSet, Value: ""}},
+		{"tags+=perf", FieldChange{Field: "tags", Op: OpAdd, Value: "perf"}},
+		{"tags-=ui", FieldChange{Field: "tags", Op: OpRemove, Value: "ui"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFieldChange(tt.input)
+		if err != nil {
+			t.Errorf("ParseFieldChange(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFieldChange(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseFieldChangeErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"priority", "expected field=value"},
+		{"=1", "expected field=value"},
+		{"id=abc", `unknown field "id"`},
+		{"created=2026", `unknown field "created"`},
+		{"title+=x", "+= only applies to tags"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFieldChange(tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseFieldChange(%q) error = %v, want it to contain %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func mustParseChanges(t *testing.T, inputs ...string) []FieldChange {
+	t.Helper()
+	var changes []FieldChange
+	for _, s := range inputs {
+		c, err := ParseFieldChange(s)
+		if err != nil {
+			t.Fatalf("ParseFieldChange(%q): %v", s, err)
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+func TestSetFields(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Old", Type: "task", Priority: 2, Tags: []string{"ui", "x"}})
+	writeFile(dir, &Ticket{ID: "par", Title: "Parent"})
+
+	changes := mustParseChanges(t,
+		"title=New", "type=bug", "priority=1", "assignee=Bob", "parent=pa",
+		"external_ref=JIRA-1", "design=Plan", "acceptance=Works", "tags+=perf", "tags-=ui",
+	)
+	updated, _, err := Set(dir, []string{"aa"}, changes)
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if len(updated) != 1 {
+		t.Fatalf("expected 1 updated ticket, got %d", len(updated))
+	}
+
+	got, _ := Show(dir, "aaa")
+	if got.Title != "New" || got.Type != "bug" || got.Priority != 1 || got.Assignee != "Bob" {
+		t.Errorf("unexpected ticket: %+v", got)
+	}
+	if got.Parent != "par" {
+		t.Errorf("parent = %q, want resolved full ID par", got.Parent)
+	}
+	if got.ExternalRef != "JIRA-1" || got.Design != "Plan" || got.Acceptance != "Works" {
+		t.Errorf("unexpected text fields: %+v", got)
+	}
+	if strings.Join(got.Tags, ",") != "x,perf" {
+		t.Errorf("tags = %v, want [x perf]", got.Tags)
+	}
+	if len(got.History) != 10 {
+		t.Errorf("expected 10 history entries, got %d", len(got.History))
+	}
+}
+
+func TestSetMultipleTickets(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "First"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Second"})
+
+	if _, _, err := Set(dir, []string{"aaa", "bbb"}, mustParseChanges(t, "tags=sprint")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	for _, id := range []string{"aaa", "bbb"} {
+		got, _ := Show(dir, id)
+		if len(got.Tags) != 1 || got.Tags[0] != "sprint" {
+			t.Errorf("%s tags = %v, want [sprint]", id, got.Tags)
+		}
+	}
+}
+
+func TestSetValidation(t *testing.T) {
+	tests := []struct {
+		change string
+		want   string
+	}{
+		{"type=nope", `invalid type "nope"`},
+		{"priority=9", "invalid priority 9"},
+		{"priority=high", `invalid priority "high"`},
+		{"status=nope", `invalid status: "nope"`},
+		{"parent=zzz", "parent ticket not found: zzz"},
+		{"parent=aaa", "cannot be its own parent"},
+		{"title=", "title must not be empty"},
+	}
+
+	for _, tt := range tests {
+		dir := tempDir(t)
+		EnsureDir(dir)
+		writeFile(dir, &Ticket{ID: "aaa", Title: "First", Type: "task"})
+		writeFile(dir, &Ticket{ID: "bbb", Title: "Second", Type: "task"})
+
+		// A valid change on another ticket must not be written either
+		changes := mustParseChanges(t, "assignee=Bob", tt.change)
+		_, _, err := Set(dir, []string{"bbb", "aaa"}, changes)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Set(%s) error = %v, want it to contain %q", tt.change, err, tt.want)
+			continue
+		}
+
+		b, _ := Show(dir, "bbb")
+		if b.Assignee != "" {
+			t.Errorf("Set(%s): bbb was modified despite the error", tt.change)
+		}
+	}
+}
+
+func TestSetStatusEnforcesTransitionsAndStamps(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "First"})
+
+	if _, _, err := Set(dir, []string{"aaa"}, mustParseChanges(t, "status=closed")); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, _ := Show(dir, "aaa")
+	if got.Status != "closed" || got.ClosedAt == "" {
+		t.Errorf("expected closed with closed_at, got status=%q closed_at=%q", got.Status, got.ClosedAt)
+	}
+}
+
+func TestSetNoChangeRecordsNothing(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Same", Type: "task", Priority: 2})
+
+	path := filepath.Join(mustDirPath(t, dir), "aaa.md")
+	os.WriteFile(path, []byte("---\nid: aaa\ntype: task\npriority: 2\n---\n# Same\n"), 0644)
+	before, _ := os.ReadFile(path)
+
+	updated, _, err := Set(dir, []string{"aaa"}, mustParseChanges(t, "title=Same", "type=task", "priority=2", "status=open", "tags-=none"))
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if len(updated) != 0 {
+		t.Errorf("expected no updated tickets, got %d", len(updated))
+	}
+
+	got, _ := Show(dir, "aaa")
+	if len(got.History) != 0 || got.Updated != "" {
+		t.Errorf("expected no recorded change, got %+v", got.History)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Errorf("unchanged ticket was rewritten:\n%s", after)
+	}
+}
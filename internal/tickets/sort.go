@@ -0,0 +1,117 @@
+package tickets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKey orders tickets by one field, ascending unless Desc is set.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// sortFields lists the fields tickets can be sorted by.
+var sortFields = []string{
+	"id", "priority", "status", "type", "assignee", "title",
+	"created", "updated", "started", "closed", "due", "scheduled",
+}
+
+// ParseSortKeys parses a comma-separated sort spec like "priority,-created,title".
+// A leading "-" sorts that field in descending order.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{Field: part}
+		if strings.HasPrefix(part, "-") {
+			key = SortKey{Field: part[1:], Desc: true}
+		}
+		if !containsString(sortFields, key.Field) {
+			return nil, fmt.Errorf("unknown sort field %q: must be one of %s", key.Field, strings.Join(sortFields, ", "))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortTickets sorts tickets in place by the given keys, in order. Tickets that
+// compare equal on every key are ordered by ID.
+func SortTickets(items []*Ticket, keys []SortKey) {
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, k := range keys {
+			c := compareField(items[i], items[j], k.Field)
+			if c == 0 {
+				continue
+			}
+			if k.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return items[i].ID < items[j].ID
+	})
+}
+
+// compareField compares two tickets on one field, returning -1, 0 or 1.
+// Timestamps are RFC3339 in UTC, so they compare correctly as strings.
+func compareField(a, b *Ticket, field string) int {
+	switch field {
+	case "priority":
+		switch {
+		case a.Priority < b.Priority:
+			return -1
+		case a.Priority > b.Priority:
+			return 1
+		}
+		return 0
+	case "status":
+		return strings.Compare(displayStatus(a.Status), displayStatus(b.Status))
+	case "type":
+		return strings.Compare(a.Type, b.Type)
+	case "assignee":
+		return strings.Compare(a.Assignee, b.Assignee)
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "created":
+		return strings.Compare(a.Created, b.Created)
+	case "updated":
+		return strings.Compare(a.Updated, b.Updated)
+	case "started":
+		return strings.Compare(a.StartedAt, b.StartedAt)
+	case "closed":
+		return strings.Compare(a.closedTime(), b.closedTime())
+	case "due":
+		return compareDates(a.Due, b.Due)
+	case "scheduled":
+		return compareDates(a.Scheduled, b.Scheduled)
+	default: // id
+		return strings.Compare(a.ID, b.ID)
+	}
+}
+
+// compareDates compares two YYYY-MM-DD dates. Unset dates sort after every date.
+func compareDates(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+// displayStatus returns the status, or "open" when it is empty (the default).
+func displayStatus(status string) string {
+	if status == "" {
+		return "open"
+	}
+	return status
+}
@@ -0,0 +1,98 @@
+package tickets
+
+import (
+	"strings"
+	"testing"
+)
+
+func ticketIDs(items []*Ticket) string {
+	var ids []string
+	for _, t := range items {
+		ids = append(ids, t.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestParseSortKeys(t *testing.T) {
+	keys, err := ParseSortKeys("priority, -created,title")
+	if err != nil {
+		t.Fatalf("ParseSortKeys: %v", err)
+	}
+	want := []SortKey{{Field: "priority"}, {Field: "created", Desc: true}, {Field: "title"}}
+	if len(keys) != len(want) {
+		t.Fatalf("keys = %+v, want %+v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("key %d = %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+
+	if keys, err := ParseSortKeys(""); err != nil || len(keys) != 0 {
+		t.Errorf("ParseSortKeys(\"\") = %v, %v; want no keys", keys, err)
+	}
+
+	if _, err := ParseSortKeys("priority,color"); err == nil || !strings.Contains(err.Error(), `unknown sort field "color"`) {
+		t.Errorf("expected unknown sort field error, got %v", err)
+	}
+}
+
+func TestSortTickets(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", Title: "beta", Priority: 2, Created: "2026-01-02T00:00:00Z"},
+		{ID: "bbb", Title: "Alpha", Priority: 1, Created: "2026-01-01T00:00:00Z"},
+		{ID: "ccc", Title: "gamma", Priority: 1, Created: "2026-01-03T00:00:00Z"},
+		{ID: "ddd", Title: "alpha", Priority: 2, Created: "2026-01-02T00:00:00Z"},
+	}
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", "aaa bbb ccc ddd"},
+		{"priority", "bbb ccc aaa ddd"},
+		{"priority,-created", "ccc bbb aaa ddd"},
+		{"-created,title", "ccc ddd aaa bbb"},
+		{"title", "bbb ddd aaa ccc"}, // case-insensitive, ties by ID
+		{"-id", "ddd ccc bbb aaa"},
+	}
+
+	for _, tt := range tests {
+		keys, err := ParseSortKeys(tt.spec)
+		if err != nil {
+			t.Fatalf("ParseSortKeys(%q): %v", tt.spec, err)
+		}
+		sorted := append([]*Ticket(nil), items...)
+		SortTickets(sorted, keys)
+		if got := ticketIDs(sorted); got != tt.want {
+			t.Errorf("sort %q = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestSortTicketsClosedFallsBackToUpdated(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", ClosedAt: "2026-01-03T00:00:00Z"},
+		{ID: "bbb", Updated: "2026-01-02T00:00:00Z"},
+		{ID: "ccc", ClosedAt: "2026-01-01T00:00:00Z", Updated: "2026-01-04T00:00:00Z"},
+	}
+
+	SortTickets(items, []SortKey{{Field: "closed", Desc: true}})
+	if got := ticketIDs(items); got != "aaa bbb ccc" {
+		t.Errorf("sort -closed = %q, want %q", got, "aaa bbb ccc")
+	}
+}
+
+func TestSortTicketsStatusDefaultsToOpen(t *testing.T) {
+	items := []*Ticket{
+		{ID: "aaa", Status: "open"},
+		{ID: "bbb", Status: "in_progress"},
+		{ID: "ccc"},
+		{ID: "ddd", Status: "closed"},
+	}
+
+	SortTickets(items, []SortKey{{Field: "status"}})
+	if got := ticketIDs(items); got != "ddd bbb aaa ccc" {
+		t.Errorf("sort by status = %q, want %q", got, "ddd bbb aaa ccc")
+	}
+}
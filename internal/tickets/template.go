@@ -0,0 +1,188 @@
+package tickets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/juanibiapina/todo/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplatesDirName is the name of the templates directory inside the tickets directory.
+const TemplatesDirName = "templates"
+
+// TemplatesDirPath returns the path to the templates directory in the given directory.
+func TemplatesDirPath(dir string) (string, error) {
+	ticketsDir, err := DirPath(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(ticketsDir, TemplatesDirName), nil
+}
+
+// Template pre-fills new tickets. It is read from templates/<name>.md in the
+// tickets directory: an optional YAML frontmatter with type, priority, tags,
+// design, acceptance and estimate, followed by the description. The description,
+// design and acceptance are Go templates executed with TemplateData.
+type Template struct {
+	Name        string
+	Type        string
+	Priority    *int
+	Tags        []string
+	Design      string
+	Acceptance  string
+	Estimate    string
+	Description string
+}
+
+// TemplateData is the data ticket templates are executed with.
+type TemplateData struct {
+	Title string
+	Date  string // today, YYYY-MM-DD
+}
+
+// templateFrontmatter is the frontmatter of a template file.
+type templateFrontmatter struct {
+	Type       string   `yaml:"type"`
+	Priority   *int     `yaml:"priority"`
+	Tags       []string `yaml:"tags"`
+	Design     string   `yaml:"design"`
+	Acceptance string   `yaml:"acceptance"`
+	Estimate   string   `yaml:"estimate"`
+}
+
+// LoadTemplate reads the named template. Returns an error if it doesn't exist.
+func LoadTemplate(dir string, name string) (*Template, error) {
+	tp, err := readTemplate(dir, name)
+	if errors.Is(err, os.ErrNotExist) {
+		templatesDir, err := TemplatesDirPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(templatesDir, name+".md")
+		if rel, err := filepath.Rel(dir, path); err == nil {
+			path = rel
+		}
+		return nil, fmt.Errorf("template not found: %s (expected %s)", name, path)
+	}
+	return tp, err
+}
+
+// FindTemplate returns the default template for a ticket type, or nil if there is none.
+func FindTemplate(dir string, ticketType string) (*Template, error) {
+	if ticketType == "" {
+		return nil, nil
+	}
+	tp, err := readTemplate(dir, ticketType)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return tp, err
+}
+
+// readTemplate parses templates/<name>.md and checks its type, priority and
+// estimate against the config.
+func readTemplate(dir string, name string) (*Template, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+
+	templatesDir, err := TemplatesDirPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(templatesDir, name+".md"))
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(data)
+	var fm templateFrontmatter
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		closingIdx := strings.Index(rest, "\n---\n")
+		if closingIdx < 0 {
+			return nil, fmt.Errorf("template %s: missing frontmatter closing delimiter", name)
+		}
+		if err := yaml.Unmarshal([]byte(rest[:closingIdx]), &fm); err != nil {
+			return nil, fmt.Errorf("template %s: invalid frontmatter YAML: %w", name, err)
+		}
+		content = rest[closingIdx+5:]
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if fm.Type != "" {
+		if err := cfg.CheckType(fm.Type); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+	if fm.Priority != nil {
+		if err := cfg.CheckPriority(*fm.Priority); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+	if fm.Estimate != "" {
+		if _, err := ParseEstimate(fm.Estimate); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+
+	return &Template{
+		Name:        name,
+		Type:        fm.Type,
+		Priority:    fm.Priority,
+		Tags:        fm.Tags,
+		Design:      fm.Design,
+		Acceptance:  fm.Acceptance,
+		Estimate:    fm.Estimate,
+		Description: strings.Trim(content, "\n"),
+	}, nil
+}
+
+// Apply fills the ticket's empty description, design, acceptance and estimate
+// from the template and adds the template's tags. Type and priority are left to
+// the caller, since flags and config defaults decide whether the template's values apply.
+func (tp *Template) Apply(t *Ticket, now time.Time) error {
+	data := TemplateData{Title: t.Title, Date: now.Format(DateLayout)}
+
+	for _, f := range []struct {
+		name string
+		src  string
+		dst  *string
+	}{
+		{"description", tp.Description, &t.Description},
+		{"design", tp.Design, &t.Design},
+		{"acceptance", tp.Acceptance, &t.Acceptance},
+	} {
+		if *f.dst != "" || f.src == "" {
+			continue
+		}
+		tmpl, err := template.New(f.name).Option("missingkey=error").Parse(f.src)
+		if err != nil {
+			return fmt.Errorf("template %s: %s: %w", tp.Name, f.name, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return fmt.Errorf("template %s: %s: %w", tp.Name, f.name, err)
+		}
+		*f.dst = strings.TrimRight(b.String(), "\n")
+	}
+
+	if t.Estimate == "" {
+		t.Estimate = tp.Estimate
+	}
+
+	for _, tag := range tp.Tags {
+		if !containsString(t.Tags, tag) {
+			t.Tags = append(t.Tags, tag)
+		}
+	}
+	return nil
+}
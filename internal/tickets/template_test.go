@@ -0,0 +1,115 @@
+package tickets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	tplDir, err := TemplatesDirPath(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(tplDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tplDir, name+".md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTemplateApply(t *testing.T) {
+	dir := tempDir(t)
+	writeTemplate(t, dir, "bug", `---
+priority: 0
+tags: [bug, triage]
+acceptance: Regression test for {{.Title}}
+estimate: 2h
+---
+Reported {{.Date}}.
+
+## Steps to reproduce
+`)
+
+	tp, err := FindTemplate(dir, "bug")
+	if err != nil || tp == nil {
+		t.Fatalf("FindTemplate = %v, %v", tp, err)
+	}
+	if tp.Priority == nil || *tp.Priority != 0 {
+		t.Errorf("priority = %v, want 0", tp.Priority)
+	}
+
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	ticket := &Ticket{Title: "Login crash", Tags: []string{"ui", "bug"}}
+	if err := tp.Apply(ticket, now); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if ticket.Description != "Reported 2026-10-14.\n\n## Steps to reproduce" {
+		t.Errorf("description = %q", ticket.Description)
+	}
+	if ticket.Acceptance != "Regression test for Login crash" {
+		t.Errorf("acceptance = %q", ticket.Acceptance)
+	}
+	if ticket.Estimate != "2h" {
+		t.Errorf("estimate = %q", ticket.Estimate)
+	}
+	if strings.Join(ticket.Tags, ",") != "ui,bug,triage" {
+		t.Errorf("tags = %v", ticket.Tags)
+	}
+
+	// Values that are already set win over the template
+	given := &Ticket{Title: "X", Description: "given", Acceptance: "mine"}
+	if err := tp.Apply(given, now); err != nil {
+		t.Fatal(err)
+	}
+	if given.Description != "given" || given.Acceptance != "mine" {
+		t.Errorf("template overwrote given values: %+v", given)
+	}
+}
+
+func TestFindTemplateMissing(t *testing.T) {
+	dir := tempDir(t)
+
+	tp, err := FindTemplate(dir, "feature")
+	if tp != nil || err != nil {
+		t.Errorf("FindTemplate without a template = %v, %v", tp, err)
+	}
+
+	if _, err := LoadTemplate(dir, "nope"); err == nil || !strings.Contains(err.Error(), "template not found: nope") {
+		t.Errorf("LoadTemplate error = %v", err)
+	}
+	if _, err := LoadTemplate(dir, "../bug"); err == nil {
+		t.Error("template names must not contain paths")
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	dir := tempDir(t)
+	writeTemplate(t, dir, "plain", "Just a body with {{.Nope}}\n")
+	writeTemplate(t, dir, "broken", "---\ntags: [a\n")
+
+	tp, err := LoadTemplate(dir, "plain")
+	if err != nil {
+		t.Fatalf("a template without frontmatter should load: %v", err)
+	}
+	if err := tp.Apply(&Ticket{Title: "X"}, time.Now()); err == nil {
+		t.Error("unknown template variables should fail")
+	}
+
+	if _, err := LoadTemplate(dir, "broken"); err == nil {
+		t.Error("a template with unterminated frontmatter should fail")
+	}
+
+	writeTemplate(t, dir, "weird", "---\ntype: weird\n---\n")
+	writeTemplate(t, dir, "urgent", "---\npriority: 9\n---\n")
+	if _, err := LoadTemplate(dir, "weird"); err == nil || !strings.Contains(err.Error(), `template weird: invalid type "weird"`) {
+		t.Errorf("a template with a type not in the config should fail, got %v", err)
+	}
+	if _, err := FindTemplate(dir, "urgent"); err == nil || !strings.Contains(err.Error(), "template urgent: invalid priority 9") {
+		t.Errorf("a template with a priority not in the config should fail, got %v", err)
+	}
+}
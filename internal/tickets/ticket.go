@@ -2,6 +2,7 @@ package tickets
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -17,31 +18,51 @@ type Ticket struct {
 	Priority    int
 	Assignee    string
 	Created     string
+	Updated     string
+	StartedAt   string
+	ClosedAt    string
 	Parent      string
 	ExternalRef string
 	Design      string
 	Acceptance  string
+	Estimate    string
+	Spent       string
+	Due         string
+	Scheduled   string
+	Recur       string
 	Deps        []string
 	Links       []string
 	Tags        []string
+	History     []HistoryEntry
+	Sessions    []WorkSession
 }
 
 // frontmatter is a helper struct for YAML marshaling of ticket metadata.
 // It excludes Title and Description which are rendered outside the frontmatter.
 type frontmatter struct {
-	ID          string   `yaml:"id"`
-	Status      string   `yaml:"status,omitempty"`
-	Type        string   `yaml:"type,omitempty"`
-	Priority    int      `yaml:"priority,omitempty"`
-	Assignee    string   `yaml:"assignee,omitempty"`
-	Created     string   `yaml:"created,omitempty"`
-	Parent      string   `yaml:"parent,omitempty"`
-	ExternalRef string   `yaml:"external_ref,omitempty"`
-	Design      string   `yaml:"design,omitempty"`
-	Acceptance  string   `yaml:"acceptance,omitempty"`
-	Deps        []string `yaml:"deps,omitempty"`
-	Links       []string `yaml:"links,omitempty"`
-	Tags        []string `yaml:"tags,omitempty"`
+	ID          string         `yaml:"id"`
+	Status      string         `yaml:"status,omitempty"`
+	Type        string         `yaml:"type,omitempty"`
+	Priority    int            `yaml:"priority,omitempty"`
+	Assignee    string         `yaml:"assignee,omitempty"`
+	Created     string         `yaml:"created,omitempty"`
+	Updated     string         `yaml:"updated,omitempty"`
+	StartedAt   string         `yaml:"started_at,omitempty"`
+	ClosedAt    string         `yaml:"closed_at,omitempty"`
+	Parent      string         `yaml:"parent,omitempty"`
+	ExternalRef string         `yaml:"external_ref,omitempty"`
+	Design      string         `yaml:"design,omitempty"`
+	Acceptance  string         `yaml:"acceptance,omitempty"`
+	Estimate    string         `yaml:"estimate,omitempty"`
+	Spent       string         `yaml:"spent,omitempty"`
+	Due         string         `yaml:"due,omitempty"`
+	Scheduled   string         `yaml:"scheduled,omitempty"`
+	Recur       string         `yaml:"recur,omitempty"`
+	Deps        []string       `yaml:"deps,omitempty"`
+	Links       []string       `yaml:"links,omitempty"`
+	Tags        []string       `yaml:"tags,omitempty"`
+	History     []HistoryEntry `yaml:"history,omitempty"`
+	Sessions    []WorkSession  `yaml:"sessions,omitempty"`
 }
 
 // String returns a formatted single-line representation: "ID Title"
@@ -49,6 +70,27 @@ func (t *Ticket) String() string {
 	return fmt.Sprintf("%s %s", t.ID, t.Title)
 }
 
+// closedTime returns the best known time the ticket was closed: closed_at,
+// falling back to updated for tickets closed before closed_at was recorded.
+func (t *Ticket) closedTime() string {
+	if t.ClosedAt != "" {
+		return t.ClosedAt
+	}
+	return t.Updated
+}
+
+// SortRecentlyClosed sorts tickets by close time, most recent first.
+// Tickets without timestamps sort last. Ties are broken by ID.
+func SortRecentlyClosed(tickets []*Ticket) {
+	sort.SliceStable(tickets, func(i, j int) bool {
+		ti, tj := tickets[i].closedTime(), tickets[j].closedTime()
+		if ti != tj {
+			return ti > tj
+		}
+		return tickets[i].ID < tickets[j].ID
+	})
+}
+
 // FullString returns the full markdown representation of a ticket
 // in YAML-frontmatter-first format.
 func (t *Ticket) FullString() string {
@@ -59,13 +101,23 @@ func (t *Ticket) FullString() string {
 		Priority:    t.Priority,
 		Assignee:    t.Assignee,
 		Created:     t.Created,
+		Updated:     t.Updated,
+		StartedAt:   t.StartedAt,
+		ClosedAt:    t.ClosedAt,
 		Parent:      t.Parent,
 		ExternalRef: t.ExternalRef,
 		Design:      t.Design,
 		Acceptance:  t.Acceptance,
+		Estimate:    t.Estimate,
+		Spent:       t.Spent,
+		Due:         t.Due,
+		Scheduled:   t.Scheduled,
+		Recur:       t.Recur,
 		Deps:        t.Deps,
 		Links:       t.Links,
 		Tags:        t.Tags,
+		History:     t.History,
+		Sessions:    t.Sessions,
 	}
 
 	yamlBytes, err := yaml.Marshal(fm)
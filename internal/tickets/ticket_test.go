@@ -252,3 +252,24 @@ func TestStringIgnoresOtherFields(t *testing.T) {
 		t.Errorf("String() = %q, want %q", got, want)
 	}
 }
+
+func TestSortRecentlyClosed(t *testing.T) {
+	tickets := []*Ticket{
+		{ID: "old", ClosedAt: "2025-01-01T01:00:00Z"},
+		{ID: "nts"},
+		{ID: "leg", Updated: "2025-01-01T02:00:00Z"},
+		{ID: "new", ClosedAt: "2025-01-01T03:00:00Z", Updated: "2025-01-01T00:00:00Z"},
+	}
+
+	SortRecentlyClosed(tickets)
+
+	var ids []string
+	for _, tk := range tickets {
+		ids = append(ids, tk.ID)
+	}
+	got := strings.Join(ids, ",")
+	want := "new,leg,old,nts"
+	if got != want {
+		t.Errorf("order = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package tickets
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimerRunning is returned by StartTimer when the ticket's timer is already running.
+var ErrTimerRunning = errors.New("timer already running")
+
+// WorkSession is a period of work on a ticket, recorded by `todo track`.
+// A session without an end is still running.
+type WorkSession struct {
+	Start string `yaml:"start" json:"start"`
+	End   string `yaml:"end,omitempty" json:"end,omitempty"`
+	Actor string `yaml:"actor,omitempty" json:"actor,omitempty"`
+}
+
+// Duration returns the length of the session. A running session lasts until now.
+// Returns 0 if a timestamp can't be parsed.
+func (s WorkSession) Duration() time.Duration {
+	start, end, ok := s.bounds(time.Now())
+	if !ok {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// bounds returns the session's start and end, using now for a running session.
+func (s WorkSession) bounds(now time.Time) (time.Time, time.Time, bool) {
+	start, err := time.Parse(time.RFC3339, s.Start)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end := now
+	if s.End != "" {
+		end, err = time.Parse(time.RFC3339, s.End)
+		if err != nil || end.Before(start) {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return start, end, true
+}
+
+// RunningSession returns the ticket's running work session, or nil.
+func (t *Ticket) RunningSession() *WorkSession {
+	for i := range t.Sessions {
+		if t.Sessions[i].End == "" {
+			return &t.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// SpentDuration returns the ticket's parsed spent time, or 0 when it has none
+// or it can't be parsed.
+func (t *Ticket) SpentDuration() time.Duration {
+	if t.Spent == "" {
+		return 0
+	}
+	d, err := ParseEstimate(t.Spent)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// formatSpent renders spent time like FormatEstimate, keeping the seconds so that
+// short sessions add up instead of being truncated to minutes on every stop.
+func formatSpent(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	seconds := d % time.Minute
+	if seconds == 0 {
+		return FormatEstimate(d)
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", seconds/time.Second)
+	}
+	return fmt.Sprintf("%s %ds", FormatEstimate(d-seconds), seconds/time.Second)
+}
+
+// StartTimer starts a work session on the given ticket, recording its start in the
+// history. Returns an error wrapping ErrTimerRunning if one is already running.
+func StartTimer(dir string, id string) (*Ticket, error) {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	path, err := findTicketFile(dir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if s := t.RunningSession(); s != nil {
+		return nil, fmt.Errorf("%w for %s since %s", ErrTimerRunning, t.ID, s.Start)
+	}
+
+	now := currentTimestamp()
+	t.Sessions = append(t.Sessions, WorkSession{Start: now, Actor: actor})
+	recordChange(actor, t, "sessions", "", now)
+
+	if err := atomicWriteFile(path, []byte(t.FullString())); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// StopTimer ends the running work session on the given ticket, adds its length
+// to the ticket's spent time (recorded in the history) and returns the session length.
+func StopTimer(dir string, id string) (*Ticket, time.Duration, error) {
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer unlock()
+
+	actor := gitActor(dir)
+
+	path, err := findTicketFile(dir, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	t, err := parseFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s := t.RunningSession()
+	if s == nil {
+		return nil, 0, fmt.Errorf("no timer running for %s", t.ID)
+	}
+	s.End = currentTimestamp()
+	d := s.Duration()
+
+	spent := formatSpent(t.SpentDuration() + d)
+	setField(actor, t, "spent", &t.Spent, spent)
+	t.Updated = s.End
+
+	if err := atomicWriteFile(path, []byte(t.FullString())); err != nil {
+		return nil, 0, err
+	}
+	return t, d, nil
+}
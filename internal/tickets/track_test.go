@@ -0,0 +1,88 @@
+package tickets
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStartStopTimer(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Fix login", Spent: "1h"})
+
+	if _, err := StartTimer(dir, "aaa"); err != nil {
+		t.Fatalf("StartTimer: %v", err)
+	}
+	if _, err := StartTimer(dir, "aaa"); !errors.Is(err, ErrTimerRunning) {
+		t.Errorf("second StartTimer should fail with ErrTimerRunning, got %v", err)
+	}
+
+	got, err := Show(dir, "aaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.RunningSession() == nil {
+		t.Fatal("expected a running session after StartTimer")
+	}
+	if len(got.History) != 1 || got.History[0].Field != "sessions" || got.History[0].New != got.Sessions[0].Start {
+		t.Errorf("timer start should be recorded in history, got %+v", got.History)
+	}
+
+	// Backdate the session so stopping it adds a measurable amount
+	got.Sessions[0].Start = time.Now().Add(-90 * time.Minute).UTC().Format(time.RFC3339)
+	writeFile(dir, got)
+
+	stopped, d, err := StopTimer(dir, "aaa")
+	if err != nil {
+		t.Fatalf("StopTimer: %v", err)
+	}
+	if d < 90*time.Minute || d > 91*time.Minute {
+		t.Errorf("session duration = %v, want about 1h30m", d)
+	}
+	if spent := stopped.SpentDuration(); spent < 150*time.Minute || spent > 151*time.Minute {
+		t.Errorf("spent = %q, want about 2h 30m", stopped.Spent)
+	}
+	if stopped.RunningSession() != nil {
+		t.Error("session should be stopped")
+	}
+
+	got, _ = Show(dir, "aaa")
+	if got.Spent != stopped.Spent || len(got.Sessions) != 1 || got.Sessions[0].End == "" {
+		t.Errorf("persisted ticket: spent %q, sessions %+v", got.Spent, got.Sessions)
+	}
+	if n := len(got.History); n == 0 || got.History[n-1].Field != "spent" {
+		t.Errorf("spent change should be recorded in history, got %+v", got.History)
+	}
+
+	if _, _, err := StopTimer(dir, "aaa"); err == nil {
+		t.Error("StopTimer without a running timer should fail")
+	}
+}
+
+func TestStopTimerKeepsSeconds(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Short sessions"})
+
+	// Two 40 second sessions add up to more than a minute
+	for i := 0; i < 2; i++ {
+		if _, err := StartTimer(dir, "aaa"); err != nil {
+			t.Fatalf("StartTimer: %v", err)
+		}
+		got, _ := Show(dir, "aaa")
+		got.RunningSession().Start = time.Now().Add(-40 * time.Second).UTC().Format(time.RFC3339)
+		writeFile(dir, got)
+		if _, _, err := StopTimer(dir, "aaa"); err != nil {
+			t.Fatalf("StopTimer: %v", err)
+		}
+	}
+
+	got, _ := Show(dir, "aaa")
+	if spent := got.SpentDuration(); spent < 80*time.Second || spent > 82*time.Second {
+		t.Errorf("spent = %q, want about 1m 20s", got.Spent)
+	}
+	if got := FormatEstimate(got.SpentDuration()); got != "1m" {
+		t.Errorf("displayed spent = %q, want %q", got, "1m")
+	}
+}
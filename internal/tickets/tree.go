@@ -7,27 +7,60 @@ import (
 	"strings"
 )
 
-// treeNode represents a node in the dependency tree.
-type treeNode struct {
-	ticket   *Ticket
-	children []*treeNode
-	marker   string // "", "(cycle)", or "(dup)"
+// TreeNode represents a node in the dependency tree.
+type TreeNode struct {
+	Ticket   *Ticket
+	Children []*TreeNode
+	Marker   string // "", "(cycle)", or "(dup)"
 }
 
 // DepTree generates a dependency tree string for the given ticket ID.
 // If full is true, deduplication is disabled (same ticket can appear multiple times).
 func DepTree(dir string, id string, full bool) (string, error) {
+	rootNode, err := BuildDepTree(dir, id, full)
+	if err != nil {
+		return "", err
+	}
+	return formatTree(rootNode), nil
+}
+
+// ReverseDepTree generates the reverse dependency tree string for the given ticket
+// ID: the tickets that depend on it, transitively.
+func ReverseDepTree(dir string, id string, full bool) (string, error) {
+	rootNode, err := BuildReverseDepTree(dir, id, full)
+	if err != nil {
+		return "", err
+	}
+	return formatTree(rootNode), nil
+}
+
+// BuildDepTree builds the dependency tree for the given ticket ID. Tickets already
+// on the current path are marked "(cycle)" and, unless full is true, tickets already
+// expanded elsewhere are marked "(dup)"; neither is expanded further.
+func BuildDepTree(dir string, id string, full bool) (*TreeNode, error) {
+	return buildTree(dir, id, full, false)
+}
+
+// BuildReverseDepTree builds the tree of tickets that depend on the given ticket
+// ID, transitively: the children of a node are the tickets it blocks. Markers work
+// as in BuildDepTree.
+func BuildReverseDepTree(dir string, id string, full bool) (*TreeNode, error) {
+	return buildTree(dir, id, full, true)
+}
+
+// buildTree builds a dependency tree following deps, or dependents if reverse is true.
+func buildTree(dir string, id string, full bool, reverse bool) (*TreeNode, error) {
 	// Resolve the ticket ID (supports partial matching)
 	path, err := findTicketFile(dir, id)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	resolvedID := strings.TrimSuffix(filepath.Base(path), ".md")
 
 	// Load all tickets and build a lookup map
 	allTickets, err := List(dir)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	ticketMap := make(map[string]*Ticket)
 	for _, t := range allTickets {
@@ -36,32 +69,42 @@ func DepTree(dir string, id string, full bool) (string, error) {
 
 	root, ok := ticketMap[resolvedID]
 	if !ok {
-		return "", fmt.Errorf("ticket not found: %s", id)
+		return nil, fmt.Errorf("ticket not found: %s", id)
+	}
+
+	next := func(t *Ticket) []string { return t.Deps }
+	if reverse {
+		dependents := make(map[string][]string)
+		for _, t := range allTickets {
+			for _, depID := range t.Deps {
+				dependents[depID] = append(dependents[depID], t.ID)
+			}
+		}
+		next = func(t *Ticket) []string { return dependents[t.ID] }
 	}
 
 	// Build tree with cycle and dedup tracking
 	ancestors := make(map[string]bool)
 	visited := make(map[string]bool)
-	rootNode := buildTreeNode(root, ticketMap, ancestors, visited, full)
-
-	return formatTree(rootNode), nil
+	return buildTreeNode(root, ticketMap, next, ancestors, visited, full), nil
 }
 
-// buildTreeNode recursively builds a tree node from a ticket.
+// buildTreeNode recursively builds a tree node from a ticket, with a child for
+// each ticket ID returned by next.
 // ancestors tracks the current path for cycle detection.
 // visited tracks all expanded nodes for dedup (when full is false).
-func buildTreeNode(t *Ticket, ticketMap map[string]*Ticket, ancestors, visited map[string]bool, full bool) *treeNode {
-	node := &treeNode{ticket: t}
+func buildTreeNode(t *Ticket, ticketMap map[string]*Ticket, next func(*Ticket) []string, ancestors, visited map[string]bool, full bool) *TreeNode {
+	node := &TreeNode{Ticket: t}
 
 	// Cycle detection: this ticket is an ancestor in the current path
 	if ancestors[t.ID] {
-		node.marker = "(cycle)"
+		node.Marker = "(cycle)"
 		return node
 	}
 
 	// Dedup: this ticket was already expanded elsewhere (default mode only)
 	if !full && visited[t.ID] {
-		node.marker = "(dup)"
+		node.Marker = "(dup)"
 		return node
 	}
 
@@ -70,14 +113,14 @@ func buildTreeNode(t *Ticket, ticketMap map[string]*Ticket, ancestors, visited m
 	visited[t.ID] = true
 	defer func() { delete(ancestors, t.ID) }()
 
-	// Build children from deps
-	var children []*treeNode
-	for _, depID := range t.Deps {
+	// Build children from deps (or dependents)
+	var children []*TreeNode
+	for _, depID := range next(t) {
 		depTicket, ok := ticketMap[depID]
 		if !ok {
 			continue // skip missing deps
 		}
-		child := buildTreeNode(depTicket, ticketMap, ancestors, visited, full)
+		child := buildTreeNode(depTicket, ticketMap, next, ancestors, visited, full)
 		children = append(children, child)
 	}
 
@@ -88,21 +131,21 @@ func buildTreeNode(t *Ticket, ticketMap map[string]*Ticket, ancestors, visited m
 		if di != dj {
 			return di > dj
 		}
-		return children[i].ticket.ID < children[j].ticket.ID
+		return children[i].Ticket.ID < children[j].Ticket.ID
 	})
 
-	node.children = children
+	node.Children = children
 	return node
 }
 
 // subtreeDepth returns the maximum depth of a node's subtree.
 // Nodes with markers (cycle/dup) or no children have depth 0.
-func subtreeDepth(n *treeNode) int {
-	if n.marker != "" || len(n.children) == 0 {
+func subtreeDepth(n *TreeNode) int {
+	if n.Marker != "" || len(n.Children) == 0 {
 		return 0
 	}
 	maxDepth := 0
-	for _, child := range n.children {
+	for _, child := range n.Children {
 		d := subtreeDepth(child)
 		if d > maxDepth {
 			maxDepth = d
@@ -113,15 +156,15 @@ func subtreeDepth(n *treeNode) int {
 
 // formatTree renders a tree node and its children with box-drawing characters.
 // Returns the formatted string without a trailing newline.
-func formatTree(root *treeNode) string {
+func formatTree(root *TreeNode) string {
 	var b strings.Builder
 	b.WriteString(formatNodeLine(root))
-	formatChildren(&b, root.children, "")
+	formatChildren(&b, root.Children, "")
 	return strings.TrimRight(b.String(), "\n")
 }
 
 // formatChildren recursively renders child nodes with appropriate prefixes.
-func formatChildren(b *strings.Builder, children []*treeNode, prefix string) {
+func formatChildren(b *strings.Builder, children []*TreeNode, prefix string) {
 	for i, child := range children {
 		isLast := i == len(children)-1
 
@@ -135,26 +178,26 @@ func formatChildren(b *strings.Builder, children []*treeNode, prefix string) {
 		b.WriteString(formatNodeLine(child))
 
 		// Only recurse into children if no marker (cycle/dup stops expansion)
-		if child.marker == "" {
+		if child.Marker == "" {
 			childPrefix := prefix + "│   "
 			if isLast {
 				childPrefix = prefix + "    "
 			}
-			formatChildren(b, child.children, childPrefix)
+			formatChildren(b, child.Children, childPrefix)
 		}
 	}
 }
 
 // formatNodeLine renders a single node as "id [status] Title [marker]\n".
-func formatNodeLine(n *treeNode) string {
+func formatNodeLine(n *TreeNode) string {
 	var parts []string
-	parts = append(parts, n.ticket.ID)
-	if n.ticket.Status != "" {
-		parts = append(parts, fmt.Sprintf("[%s]", n.ticket.Status))
+	parts = append(parts, n.Ticket.ID)
+	if n.Ticket.Status != "" {
+		parts = append(parts, fmt.Sprintf("[%s]", n.Ticket.Status))
 	}
-	parts = append(parts, n.ticket.Title)
-	if n.marker != "" {
-		parts = append(parts, n.marker)
+	parts = append(parts, n.Ticket.Title)
+	if n.Marker != "" {
+		parts = append(parts, n.Marker)
 	}
 	return strings.Join(parts, " ") + "\n"
 }
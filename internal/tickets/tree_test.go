@@ -159,3 +159,60 @@ func TestDepTreeMissingDep(t *testing.T) {
 		t.Errorf("got:\n%s\nwant:\n%s", result, expected)
 	}
 }
+
+func TestBuildDepTree(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Root", Deps: []string{"bbb", "ccc"}})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "First", Deps: []string{"ccc", "aaa"}})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Shared"})
+
+	root, err := BuildDepTree(dir, "aa", false)
+	if err != nil {
+		t.Fatalf("BuildDepTree: %v", err)
+	}
+
+	if root.Ticket.ID != "aaa" || len(root.Children) != 2 {
+		t.Fatalf("root = %s with %d children", root.Ticket.ID, len(root.Children))
+	}
+	first := root.Children[0]
+	if first.Ticket.ID != "bbb" || first.Marker != "" || len(first.Children) != 2 {
+		t.Fatalf("first child = %+v", first)
+	}
+	if first.Children[0].Ticket.ID != "aaa" || first.Children[0].Marker != "(cycle)" {
+		t.Errorf("expected cycle marker on aaa, got %+v", first.Children[0])
+	}
+	if dup := root.Children[1]; dup.Ticket.ID != "ccc" || dup.Marker != "(dup)" {
+		t.Errorf("expected dup marker on ccc, got %+v", dup)
+	}
+
+	if _, err := BuildDepTree(dir, "zzz", false); err == nil {
+		t.Error("expected error for missing ticket")
+	}
+}
+
+func TestReverseDepTree(t *testing.T) {
+	dir := tempDir(t)
+	EnsureDir(dir)
+
+	writeFile(dir, &Ticket{ID: "aaa", Title: "Blocker"})
+	writeFile(dir, &Ticket{ID: "bbb", Title: "Mid", Deps: []string{"aaa"}})
+	writeFile(dir, &Ticket{ID: "ccc", Title: "Top", Deps: []string{"bbb", "aaa"}})
+	writeFile(dir, &Ticket{ID: "ddd", Title: "Unrelated", Deps: []string{"ccc"}, Status: "closed"})
+
+	result, err := ReverseDepTree(dir, "aaa", false)
+	if err != nil {
+		t.Fatalf("ReverseDepTree: %v", err)
+	}
+
+	expected := "aaa Blocker\n├── bbb Mid\n│   └── ccc Top\n│       └── ddd [closed] Unrelated\n└── ccc Top (dup)"
+	if result != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", result, expected)
+	}
+
+	result, _ = ReverseDepTree(dir, "ddd", false)
+	if result != "ddd [closed] Unrelated" {
+		t.Errorf("a ticket nothing depends on should have no children, got:\n%s", result)
+	}
+}
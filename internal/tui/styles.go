@@ -70,4 +70,7 @@ var (
 	statusActiveStyle  = lipgloss.NewStyle().Foreground(successColor)
 	statusDefaultStyle = lipgloss.NewStyle().Foreground(fgColor)
 	statusClosedStyle  = lipgloss.NewStyle().Foreground(mutedColor)
+
+	// List badges — due date
+	overdueStyle = lipgloss.NewStyle().Foreground(dangerColor).Bold(true)
 )
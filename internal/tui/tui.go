@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -16,6 +15,7 @@ import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/juanibiapina/todo/internal/config"
 	"github.com/juanibiapina/todo/internal/tickets"
 )
 
@@ -34,6 +34,7 @@ const (
 	modalNone modalMode = iota
 	modalAdd
 	modalNote
+	modalFilter
 	modalHelp
 )
 
@@ -53,10 +54,14 @@ type tickMsg time.Time
 // Model is the main TUI model
 type Model struct {
 	dir        string
+	cfg        *config.Config
 	items      []*tickets.Ticket
 	allTickets []*tickets.Ticket
+	warnings   []tickets.ParseWarning // ticket files that couldn't be parsed
 	scroll     ScrollState
 	view       viewMode
+	filter     *tickets.Filter // narrows the current view, nil for none
+	filterText string          // the expression the filter was parsed from
 
 	activePanel  panel
 	modal        modalMode
@@ -88,6 +93,7 @@ func New(dir string) Model {
 
 	return Model{
 		dir:         dir,
+		cfg:         config.Default(),
 		activePanel: panelList,
 		modal:       modalNone,
 		textInput:   ti,
@@ -102,13 +108,16 @@ func tickCmd() tea.Cmd {
 
 func (m Model) loadTickets() tea.Cmd {
 	return func() tea.Msg {
-		allItems, _ := tickets.List(m.dir)
-		return ticketsLoadedMsg{allTickets: allItems}
+		cfg, _ := config.Load(m.dir)
+		allItems, warnings, _ := tickets.ListWithWarnings(m.dir)
+		return ticketsLoadedMsg{cfg: cfg, allTickets: allItems, warnings: warnings}
 	}
 }
 
 type ticketsLoadedMsg struct {
+	cfg        *config.Config // nil if the config failed to load
 	allTickets []*tickets.Ticket
+	warnings   []tickets.ParseWarning
 }
 
 type actionDoneMsg struct {
@@ -154,7 +163,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(m.loadTickets(), tickCmd())
 
 	case ticketsLoadedMsg:
+		// Keep the last valid config if the file was broken while running
+		if msg.cfg != nil {
+			m.cfg = msg.cfg
+		}
 		m.allTickets = msg.allTickets
+		m.warnings = msg.warnings
 		m.applyView()
 		m.updateDetailContent()
 
@@ -194,15 +208,16 @@ func (m *Model) applyView() {
 		m.items = m.filterBlocked()
 	case viewClosed:
 		m.items = m.filterClosed()
-	default: // viewAll — open/in_progress (not closed)
+	default: // viewAll — tickets not done, unless the filter selects a status
 		var items []*tickets.Ticket
 		for _, t := range m.allTickets {
-			if t.Status != "closed" {
+			if !m.cfg.IsDone(t.Status) || m.filter.HasField("status") {
 				items = append(items, t)
 			}
 		}
 		m.items = items
 	}
+	m.items = tickets.FilterTickets(m.items, m.filter)
 	m.scroll.ClampToCount(len(m.items))
 }
 
@@ -214,13 +229,13 @@ func (m *Model) filterReady() []*tickets.Ticket {
 
 	var ready []*tickets.Ticket
 	for _, t := range m.allTickets {
-		if t.Status == "closed" {
+		if m.cfg.IsDone(t.Status) {
 			continue
 		}
 		allDepsDone := true
 		for _, depID := range t.Deps {
 			depStatus, exists := statusMap[depID]
-			if exists && depStatus != "closed" {
+			if exists && !m.cfg.IsDone(depStatus) {
 				allDepsDone = false
 				break
 			}
@@ -248,13 +263,13 @@ func (m *Model) filterBlocked() []*tickets.Ticket {
 
 	var blocked []*tickets.Ticket
 	for _, t := range m.allTickets {
-		if t.Status == "closed" {
+		if m.cfg.IsDone(t.Status) {
 			continue
 		}
 		hasUnclosed := false
 		for _, depID := range t.Deps {
 			depStatus, exists := statusMap[depID]
-			if exists && depStatus != "closed" {
+			if exists && !m.cfg.IsDone(depStatus) {
 				hasUnclosed = true
 				break
 			}
@@ -275,33 +290,16 @@ func (m *Model) filterBlocked() []*tickets.Ticket {
 }
 
 func (m *Model) filterClosed() []*tickets.Ticket {
-	type closedTicket struct {
-		ticket *tickets.Ticket
-		mtime  int64
-	}
-
-	var closed []closedTicket
+	var closed []*tickets.Ticket
 	for _, t := range m.allTickets {
-		if t.Status != "closed" {
-			continue
-		}
-		path := filepath.Join(tickets.DirPath(m.dir), t.ID+".md")
-		info, err := os.Stat(path)
-		if err != nil {
+		if !m.cfg.IsDone(t.Status) {
 			continue
 		}
-		closed = append(closed, closedTicket{ticket: t, mtime: info.ModTime().UnixNano()})
+		closed = append(closed, t)
 	}
 
-	sort.Slice(closed, func(i, j int) bool {
-		return closed[i].mtime > closed[j].mtime
-	})
-
-	items := make([]*tickets.Ticket, len(closed))
-	for i, ct := range closed {
-		items[i] = ct.ticket
-	}
-	return items
+	tickets.SortRecentlyClosed(closed)
+	return closed
 }
 
 func (m *Model) renderMarkdown(text string, width int) string {
@@ -376,10 +374,31 @@ func (m *Model) updateDetailContent() {
 		b.WriteString("\n")
 	}
 
+	// Updated
+	if t.Updated != "" {
+		b.WriteString(metaLabelStyle.Render("Updated: "))
+		b.WriteString(metaValueStyle.Render(t.Updated))
+		b.WriteString("\n")
+	}
+
+	// Started
+	if t.StartedAt != "" {
+		b.WriteString(metaLabelStyle.Render("Started: "))
+		b.WriteString(metaValueStyle.Render(t.StartedAt))
+		b.WriteString("\n")
+	}
+
+	// Closed
+	if t.ClosedAt != "" {
+		b.WriteString(metaLabelStyle.Render("Closed: "))
+		b.WriteString(metaValueStyle.Render(t.ClosedAt))
+		b.WriteString("\n")
+	}
+
 	// Parent — enhanced with resolved title if available
 	if t.Parent != "" {
 		b.WriteString(metaLabelStyle.Render("Parent: "))
-		rel := tickets.ComputeRelations(t, m.allTickets)
+		rel := tickets.ComputeRelations(t, m.allTickets, m.cfg)
 		if rel.ParentTicket != nil {
 			b.WriteString(ticketIDStyle.Render(rel.ParentTicket.ID))
 			b.WriteString(metaValueStyle.Render(" ("+rel.ParentTicket.Title+")"))
@@ -396,6 +415,45 @@ func (m *Model) updateDetailContent() {
 		b.WriteString("\n")
 	}
 
+	// Estimate
+	if t.Estimate != "" {
+		b.WriteString(metaLabelStyle.Render("Estimate: "))
+		b.WriteString(metaValueStyle.Render(t.Estimate))
+		b.WriteString("\n")
+	}
+
+	// Spent
+	if t.Spent != "" {
+		b.WriteString(metaLabelStyle.Render("Spent: "))
+		b.WriteString(metaValueStyle.Render(tickets.FormatEstimate(t.SpentDuration())))
+		b.WriteString("\n")
+	}
+
+	// Due
+	if t.Due != "" {
+		b.WriteString(metaLabelStyle.Render("Due: "))
+		if t.IsOverdue(time.Now()) && !m.cfg.IsDone(t.Status) {
+			b.WriteString(overdueStyle.Render(t.Due + " (overdue)"))
+		} else {
+			b.WriteString(metaValueStyle.Render(t.Due))
+		}
+		b.WriteString("\n")
+	}
+
+	// Scheduled
+	if t.Scheduled != "" {
+		b.WriteString(metaLabelStyle.Render("Scheduled: "))
+		b.WriteString(metaValueStyle.Render(t.Scheduled))
+		b.WriteString("\n")
+	}
+
+	// Recur
+	if t.Recur != "" {
+		b.WriteString(metaLabelStyle.Render("Recur: "))
+		b.WriteString(metaValueStyle.Render(t.Recur))
+		b.WriteString("\n")
+	}
+
 	// Tags
 	if len(t.Tags) > 0 {
 		b.WriteString(metaLabelStyle.Render("Tags: "))
@@ -457,7 +515,7 @@ func (m *Model) updateDetailContent() {
 	}
 
 	// Computed relationships
-	rel := tickets.ComputeRelations(t, m.allTickets)
+	rel := tickets.ComputeRelations(t, m.allTickets, m.cfg)
 	m.renderRelationSection(&b, "Blockers", rel.Blockers)
 	m.renderRelationSection(&b, "Blocking", rel.Blocking)
 	m.renderRelationSection(&b, "Children", rel.Children)
@@ -529,6 +587,34 @@ func (m Model) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.textInput, cmd = m.textInput.Update(msg)
 		return m, cmd
 
+	case modalFilter:
+		switch msg.String() {
+		case "esc":
+			m.modal = modalNone
+			return m, nil
+		case "enter":
+			text := strings.TrimSpace(m.textInput.Value())
+			filter, err := tickets.ParseFilter(text)
+			if err != nil {
+				m.message = err.Error()
+				m.isError = true
+				m.messageTime = time.Now()
+				return m, nil
+			}
+			m.modal = modalNone
+			m.filter = filter
+			m.filterText = text
+			m.applyView()
+			m.scroll.Reset()
+			m.updateDetailContent()
+			return m, nil
+		case "ctrl+c":
+			return m, tea.Quit
+		}
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		return m, cmd
+
 	case modalHelp:
 		switch msg.String() {
 		case "esc", "?", "q":
@@ -542,7 +628,19 @@ func (m Model) updateModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) updateMain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "q", "ctrl+c", "esc":
+	case "esc":
+		// Clear an active filter first, quit otherwise
+		if m.filter != nil {
+			m.filter = nil
+			m.filterText = ""
+			m.applyView()
+			m.scroll.Reset()
+			m.updateDetailContent()
+			return m, nil
+		}
+		return m, tea.Quit
+
+	case "q", "ctrl+c":
 		return m, tea.Quit
 
 	case "tab":
@@ -624,6 +722,14 @@ func (m Model) updateListPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.copyTicket(m.items[m.scroll.Cursor])
 		}
 
+	case "/":
+		m.modal = modalFilter
+		m.textInput.SetValue(m.filterText)
+		m.textInput.Placeholder = "priority<=1 OR tag=urgent"
+		m.textInput.Focus()
+		m.textInput.CursorEnd()
+		return m, textinput.Blink
+
 	case "1":
 		m.view = viewAll
 		m.applyView()
@@ -674,18 +780,41 @@ func (m Model) updateDetailPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) addTicket(title string) tea.Cmd {
 	return func() tea.Msg {
-		// Default assignee to git user.name
-		var assignee string
-		if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
-			assignee = strings.TrimSpace(string(out))
+		cfg, err := config.Load(m.dir)
+		if err != nil {
+			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
+		}
+
+		// Default assignee to the configured assignee, then git user.name
+		assignee := cfg.Defaults.Assignee
+		if assignee == "" {
+			if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+				assignee = strings.TrimSpace(string(out))
+			}
 		}
 
-		t, err := tickets.Add(m.dir, &tickets.Ticket{
+		t := &tickets.Ticket{
 			Title:    title,
-			Type:     "task",
-			Priority: 2,
+			Type:     cfg.Defaults.Type,
+			Priority: cfg.Defaults.Priority,
 			Assignee: assignee,
-		})
+		}
+
+		// Pre-fill from the default type's template, like todo add
+		tp, err := tickets.FindTemplate(m.dir, t.Type)
+		if err != nil {
+			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
+		}
+		if tp != nil {
+			if tp.Priority != nil {
+				t.Priority = *tp.Priority
+			}
+			if err := tp.Apply(t, time.Now()); err != nil {
+				return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
+			}
+		}
+
+		t, err = tickets.Add(m.dir, t)
 		if err != nil {
 			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
 		}
@@ -695,7 +824,7 @@ func (m Model) addTicket(title string) tea.Cmd {
 
 func (m Model) startTicket(id string) tea.Cmd {
 	return func() tea.Msg {
-		title, err := tickets.SetStatus(m.dir, id, "in_progress")
+		title, _, err := tickets.SetStatus(m.dir, id, "in_progress")
 		if err != nil {
 			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
 		}
@@ -705,17 +834,20 @@ func (m Model) startTicket(id string) tea.Cmd {
 
 func (m Model) closeTicket(id string) tea.Cmd {
 	return func() tea.Msg {
-		title, err := tickets.SetStatus(m.dir, id, "closed")
+		title, next, err := tickets.Done(m.dir, id)
 		if err != nil {
 			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
 		}
+		if next != nil {
+			return actionDoneMsg{message: fmt.Sprintf("Closed: %s (next: %s due %s)", title, next.ID, next.Due)}
+		}
 		return actionDoneMsg{message: fmt.Sprintf("Closed: %s", title)}
 	}
 }
 
 func (m Model) reopenTicket(id string) tea.Cmd {
 	return func() tea.Msg {
-		title, err := tickets.SetStatus(m.dir, id, "open")
+		title, _, err := tickets.SetStatus(m.dir, id, "open")
 		if err != nil {
 			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
 		}
@@ -728,7 +860,12 @@ func (m Model) editTicket(id string) tea.Cmd {
 	if editor == "" {
 		editor = "vi"
 	}
-	ticketPath := filepath.Join(tickets.DirPath(m.dir), id+".md")
+	ticketPath, err := tickets.FilePath(m.dir, id)
+	if err != nil {
+		return func() tea.Msg {
+			return actionDoneMsg{message: fmt.Sprintf("Error: %v", err), isError: true}
+		}
+	}
 	cmd := exec.Command(editor, ticketPath)
 	return tea.ExecProcess(cmd, func(err error) tea.Msg {
 		return editorFinishedMsg{err: err}
@@ -807,6 +944,9 @@ func (m Model) renderPanels() string {
 	default:
 		listTitle = "Tickets [All]"
 	}
+	if m.filterText != "" {
+		listTitle += " " + m.filterText
+	}
 	listContent := m.renderTicketList(leftW - 4)
 	listPanel := m.renderPanel(1, listTitle, listContent, leftW, totalH, m.activePanel == panelList)
 
@@ -846,10 +986,10 @@ func (m Model) statusBadge(status string, selected bool) string {
 	}
 	text := fmt.Sprintf("[%s]", status)
 	var style lipgloss.Style
-	switch status {
-	case "in_progress":
+	switch {
+	case status == "in_progress":
 		style = statusActiveStyle
-	case "closed":
+	case m.cfg.IsDone(status):
 		style = statusClosedStyle
 	default:
 		style = statusDefaultStyle
@@ -862,11 +1002,15 @@ func (m Model) statusBadge(status string, selected bool) string {
 
 func (m Model) renderTicketList(width int) string {
 	if len(m.items) == 0 {
+		if m.filter != nil {
+			return mutedStyle.Render("No tickets match the filter. Press esc to clear it.")
+		}
 		return mutedStyle.Render("No tickets. Press 'a' to add one.")
 	}
 
 	var lines []string
 	start, end := m.scroll.VisibleRange(len(m.items))
+	now := time.Now()
 
 	for i := start; i < end; i++ {
 		t := m.items[i]
@@ -884,13 +1028,23 @@ func (m Model) renderTicketList(width int) string {
 		prioBadge := m.priorityBadge(t.Priority, isSelected)
 		statBadge := m.statusBadge(t.Status, isSelected)
 
+		// Overdue badge
+		var dueBadge string
+		if t.IsOverdue(now) && !m.cfg.IsDone(t.Status) {
+			style := overdueStyle
+			if isSelected {
+				style = style.Background(selectionBg)
+			}
+			dueBadge = style.Render("[overdue]")
+		}
+
 		// Title (truncated)
-		// Layout: SP + ID(3) + SP + [P<n>](4) + [status](2+len) + SP + Title
+		// Layout: SP + ID(3) + SP + [P<n>](4) + [status](2+len) + [overdue] + SP + Title
 		status := t.Status
 		if status == "" {
 			status = "open"
 		}
-		prefixW := 1 + 3 + 1 + 4 + (2 + len(status)) + 1
+		prefixW := 1 + 3 + 1 + 4 + (2 + len(status)) + lipgloss.Width(dueBadge) + 1
 		maxTitleLen := width - prefixW
 		if maxTitleLen < 5 {
 			maxTitleLen = 5
@@ -910,13 +1064,13 @@ func (m Model) renderTicketList(width int) string {
 		var line string
 		if isSelected {
 			sp := selectedBgStyle.Render(" ")
-			line = sp + id + sp + prioBadge + statBadge + sp + title
+			line = sp + id + sp + prioBadge + statBadge + dueBadge + sp + title
 			padding := width - lipgloss.Width(line)
 			if padding > 0 {
 				line = line + selectedBgStyle.Render(strings.Repeat(" ", padding))
 			}
 		} else {
-			line = " " + id + " " + prioBadge + statBadge + " " + title
+			line = " " + id + " " + prioBadge + statBadge + dueBadge + " " + title
 		}
 
 		lines = append(lines, line)
@@ -1002,6 +1156,7 @@ func (m Model) renderStatusBar() string {
 				m.renderKey("e", "edit"),
 				m.renderKey("n", "note"),
 				m.renderKey("space", "copy"),
+				m.renderKey("/", "filter"),
 				m.renderKey("tab", "detail"),
 			)
 		case panelDetail:
@@ -1011,15 +1166,23 @@ func (m Model) renderStatusBar() string {
 				m.renderKey("tab", "list"),
 			)
 		}
-		parts = append(parts, m.renderKey("?", "help"), m.renderKey("esc/q", "quit"))
+		parts = append(parts, m.renderKey("?", "help"))
+		if m.filter != nil {
+			parts = append(parts, m.renderKey("esc", "clear filter"), m.renderKey("q", "quit"))
+		} else {
+			parts = append(parts, m.renderKey("esc/q", "quit"))
+		}
 
 		leftSide := strings.Join(parts, " ")
-		leftWidth := lipgloss.Width(leftSide)
-		gap := m.width - leftWidth - 2
+		rightSide := ""
+		if len(m.warnings) > 0 {
+			rightSide = errorStyle.Render(fmt.Sprintf("%d unreadable ticket file(s), run todo doctor", len(m.warnings)))
+		}
+		gap := m.width - lipgloss.Width(leftSide) - lipgloss.Width(rightSide) - 2
 		if gap < 0 {
 			gap = 0
 		}
-		content = " " + leftSide + strings.Repeat(" ", gap) + " "
+		content = " " + leftSide + strings.Repeat(" ", gap) + rightSide + " "
 	}
 
 	return statusBarStyle.Render(content)
@@ -1037,6 +1200,8 @@ func (m Model) renderModal(background string) string {
 		content = m.renderAddModal()
 	case modalNote:
 		content = m.renderNoteModal()
+	case modalFilter:
+		content = m.renderFilterModal()
 	case modalHelp:
 		content = m.renderHelpModal()
 	}
@@ -1149,6 +1314,15 @@ func (m Model) renderNoteModal() string {
 	return dialogStyle.Render(content)
 }
 
+func (m Model) renderFilterModal() string {
+	title := dialogTitleStyle.Render("Filter Tickets")
+	input := m.textInput.View()
+	help := helpDescStyle.Render("enter: apply • empty: clear • esc: cancel")
+
+	content := title + "\n\n" + input + "\n\n" + help
+	return dialogStyle.Render(content)
+}
+
 func (m Model) renderHelpModal() string {
 	title := dialogTitleStyle.Render("Keyboard Shortcuts")
 
@@ -1169,6 +1343,7 @@ func (m Model) renderHelpModal() string {
 		"  " + m.renderKey("2", "ready"),
 		"  " + m.renderKey("3", "blocked"),
 		"  " + m.renderKey("4", "closed"),
+		"  " + m.renderKey("/", "filter (e.g. priority<=1 tag=ui)"),
 		"",
 		helpKeyStyle.Render("Detail Panel"),
 		"  " + m.renderKey("↑/k ↓/j", "scroll"),
@@ -1178,7 +1353,8 @@ func (m Model) renderHelpModal() string {
 		helpKeyStyle.Render("General"),
 		"  " + m.renderKey("tab", "switch panel"),
 		"  " + m.renderKey("?", "this help"),
-		"  " + m.renderKey("esc/q", "quit"),
+		"  " + m.renderKey("esc", "clear filter / quit"),
+		"  " + m.renderKey("q", "quit"),
 	}
 
 	help := helpDescStyle.Render("\npress esc or ? to close")
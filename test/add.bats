@@ -321,3 +321,15 @@ load test_helper
   assert_output --partial "api"
   assert_output --partial "backend"
 }
+
+# --- Timestamps ---
+
+@test "add: stamps created timestamp" {
+  run todo add "Timestamped"
+  assert_success
+
+  local id
+  id="$(extract_id_from_add "${output}")"
+  run todo show "${id}"
+  assert_output --regexp 'created: "[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9:]{8}Z"'
+}
@@ -0,0 +1,96 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "archive: moves done tickets into the archive directory" {
+  local out1 out2
+  out1="$(todo add "Finished")"
+  out2="$(todo add "Pending")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  todo close "${id1}"
+
+  run todo archive
+  assert_success
+  assert_output "Archived ${id1} Finished"
+
+  [ -f "docs/tickets/archive/${id1}.md" ]
+  [ ! -f "docs/tickets/${id1}.md" ]
+  [ -f "docs/tickets/${id2}.md" ]
+}
+
+@test "archive: archived tickets are hidden from list and closed" {
+  local out
+  out="$(todo add "Finished")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo close "${id}"
+  todo archive
+
+  run todo list --status closed
+  refute_output --partial "Finished"
+
+  run todo closed
+  refute_output --partial "Finished"
+}
+
+@test "archive: show and query --archived still read archived tickets" {
+  local out
+  out="$(todo add "Finished")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo close "${id}"
+  todo archive
+
+  run todo show "${id}"
+  assert_success
+  assert_output --partial "# Finished"
+
+  run todo query
+  refute_output --partial "Finished"
+
+  run todo query --archived
+  assert_output --partial "\"title\":\"Finished\""
+}
+
+@test "archive: by ID requires a done ticket" {
+  local out
+  out="$(todo add "Pending")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo archive "${id}"
+  assert_failure
+  assert_output --partial "is not a done status"
+}
+
+@test "archive: nothing to archive" {
+  todo add "Pending"
+
+  run todo archive
+  assert_success
+  assert_output "No tickets to archive"
+}
+
+@test "archive: deps on archived tickets are not reported by check" {
+  local out1 out2
+  out1="$(todo add "Finished")"
+  out2="$(todo add "Follow-up")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  todo dep "${id2}" "${id1}"
+  todo close "${id1}"
+  todo archive
+
+  run todo check
+  assert_output "No problems found"
+
+  run todo ready
+  assert_output --partial "Follow-up"
+}
@@ -0,0 +1,148 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "bulk: closes every ticket matching --where" {
+  local out1 out2 out3
+  out1="$(todo add "Sprint one" --tags sprint-12)"
+  out2="$(todo add "Sprint two" --tags sprint-12)"
+  out3="$(todo add "Backlog")"
+  local id1 id2 id3
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+  id3="$(extract_id_from_add "${out3}")"
+
+  run todo bulk --yes --where 'tag=sprint-12' close
+  assert_success
+  assert_line "Updated ${id1} Sprint one"
+  assert_line "Updated ${id2} Sprint two"
+  refute_output --partial "Updated ${id3}"
+
+  run todo list
+  assert_output --partial "Backlog"
+  refute_output --partial "Sprint one"
+  refute_output --partial "Sprint two"
+}
+
+@test "bulk: combines filter terms with AND" {
+  todo add "Alice bug" --type bug --assignee Alice
+  todo add "Alice task" --type task --assignee Alice
+  todo add "Carol bug" --type bug --assignee Carol
+
+  run todo bulk --yes --where 'assignee=Alice type=bug status=open' set assignee=Bob
+  assert_success
+  assert_output --partial "Updated"
+
+  run todo list --assignee Bob
+  assert_output --partial "Alice bug"
+  refute_output --partial "Alice task"
+  refute_output --partial "Carol bug"
+}
+
+@test "bulk: previews and asks for confirmation" {
+  todo add "Doomed" --tags old
+
+  run bash -c "echo n | todo bulk --where 'tag=old' close"
+  assert_success
+  assert_output --partial "Doomed"
+  assert_output --partial "Apply 'close' to 1 ticket(s)? [y/N]"
+  assert_output --partial "Aborted"
+
+  run todo list
+  assert_output --partial "Doomed"
+
+  run bash -c "echo y | todo bulk --where 'tag=old' close"
+  assert_success
+
+  run todo list
+  refute_output --partial "Doomed"
+}
+
+@test "bulk: --dry-run only previews" {
+  todo add "Untouched" --tags old
+
+  run todo bulk --dry-run --where 'tag=old' start
+  assert_success
+  assert_output --partial "Untouched"
+  assert_output --partial "Would apply 'start' to 1 ticket(s)"
+
+  run todo list --status in_progress
+  assert_output ""
+}
+
+@test "bulk: reads ticket IDs from stdin" {
+  todo add "First" --tags perf
+  todo add "Second"
+
+  run bash -c "todo list --tag perf | todo bulk --yes set priority=0"
+  assert_success
+  assert_output --partial "Updated"
+
+  run todo ready
+  assert_line --index 0 --partial "[P0][open] - First"
+}
+
+@test "bulk: rejects archived IDs from stdin before changing anything" {
+  local out
+  out="$(todo add "Active")"
+  local active
+  active="$(extract_id_from_add "${out}")"
+  out="$(todo add "Old")"
+  local old
+  old="$(extract_id_from_add "${out}")"
+  todo close "${old}"
+  todo archive
+
+  run bash -c "printf '%s\n' ${active} ${old} | todo bulk --yes set priority=0"
+  assert_failure
+  assert_output --partial "ticket ${old} is archived"
+  refute_output --partial "Updated"
+
+  run todo show "${active}"
+  assert_output --partial "priority: 2"
+}
+
+@test "bulk: reports when no tickets match" {
+  todo add "Something"
+
+  run todo bulk --yes --where 'tag=missing' close
+  assert_success
+  assert_output "No tickets match"
+}
+
+@test "bulk: rejects invalid changes without modifying any ticket" {
+  todo add "First" --tags x
+  todo add "Second" --tags x
+
+  run todo bulk --yes --where 'tag=x' set priority=99
+  assert_failure
+  assert_output --partial "invalid priority 99"
+
+  run todo ready
+  assert_output --partial "[P2]"
+}
+
+@test "bulk: rejects unknown actions and filter keys" {
+  run todo bulk --yes --where 'tag=x' explode
+  assert_failure
+  assert_output --partial "unknown action \"explode\""
+
+  run todo bulk --yes --where 'color=red' close
+  assert_failure
+  assert_output --partial "unknown filter field \"color\""
+}
+
+@test "bulk: --where accepts filter expressions" {
+  todo add "Docs" --tags docs -p 3
+  todo add "Urgent" -p 0
+  todo add "Other" -p 2
+
+  run todo bulk --where 'tag=docs OR priority<1' --yes set assignee=Bob
+  assert_success
+  assert_output --partial "Updated"
+
+  run todo list assignee=Bob
+  assert_output --partial "Docs"
+  assert_output --partial "Urgent"
+  refute_output --partial "Other"
+}
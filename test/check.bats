@@ -0,0 +1,74 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "check: reports no problems for consistent tickets" {
+  local out1 out2
+  out1="$(todo add "First")"
+  out2="$(todo add "Second")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  todo dep "${id1}" "${id2}"
+  todo link "${id1}" "${id2}"
+
+  run todo check
+  assert_success
+  assert_output "No problems found"
+}
+
+@test "check: reports dangling references, one-way links and id mismatches" {
+  mkdir -p docs/tickets
+  printf -- '---\nid: aaa\ndeps:\n  - zzz\nparent: yyy\nlinks:\n  - bbb\n---\n# First\n' > docs/tickets/aaa.md
+  printf -- '---\nid: bbb\n---\n# Second\n' > docs/tickets/bbb.md
+  printf -- '---\nid: aaa\n---\n# Copy\n' > docs/tickets/ccc.md
+
+  run todo check
+  assert_success
+  assert_line "aaa: dep zzz does not exist"
+  assert_line "aaa: parent yyy does not exist"
+  assert_line "aaa: links to bbb but bbb does not link back"
+  assert_line "ccc: frontmatter id \"aaa\" does not match filename"
+  assert_line "aaa: duplicate id in aaa.md, ccc.md"
+  assert_line "5 problem(s) found, 5 fixable with --fix"
+}
+
+@test "check: reports dependency cycles" {
+  mkdir -p docs/tickets
+  printf -- '---\nid: aaa\ndeps:\n  - bbb\n---\n# First\n' > docs/tickets/aaa.md
+  printf -- '---\nid: bbb\ndeps:\n  - aaa\n---\n# Second\n' > docs/tickets/bbb.md
+
+  run todo check
+  assert_success
+  assert_line "aaa: dependency cycle aaa -> bbb -> aaa"
+  assert_line "1 problem(s) found"
+}
+
+@test "check: --fix repairs references and links" {
+  mkdir -p docs/tickets
+  printf -- '---\nid: aaa\ndeps:\n  - zzz\nlinks:\n  - bbb\n---\n# First\n' > docs/tickets/aaa.md
+  printf -- '---\nid: bbb\n---\n# Second\n' > docs/tickets/bbb.md
+
+  run todo check --fix
+  assert_success
+  assert_line "Fixed aaa: dep zzz does not exist"
+  assert_line "Fixed aaa: links to bbb but bbb does not link back"
+  assert_line "No problems found"
+
+  run todo show bbb
+  assert_output --partial "- aaa"
+
+  run todo log aaa
+  assert_output --partial "deps: - zzz"
+}
+
+@test "check: --strict fails when problems remain" {
+  mkdir -p docs/tickets
+  printf -- '---\nid: aaa\ndeps:\n  - bbb\n---\n# First\n' > docs/tickets/aaa.md
+  printf -- '---\nid: bbb\ndeps:\n  - aaa\n---\n# Second\n' > docs/tickets/bbb.md
+
+  run todo check --fix --strict
+  assert_failure
+  assert_output --partial "1 problem(s) found"
+}
@@ -42,7 +42,7 @@ load test_helper
   assert_output ""
 }
 
-@test "closed: sorted by mtime descending" {
+@test "closed: sorted by closed_at descending" {
   local out1 out2 out3
   out1="$(todo add "First closed")"
   out2="$(todo add "Second closed")"
@@ -52,15 +52,20 @@ load test_helper
   id2="$(extract_id_from_add "${out2}")"
   id3="$(extract_id_from_add "${out3}")"
 
-  # Close in order, using touch to set specific mtimes
   todo close "${id1}"
-  touch -t 202501010100 "${TODO_TEST_DIR}/docs/tickets/${id1}.md"
-
   todo close "${id2}"
-  touch -t 202501010200 "${TODO_TEST_DIR}/docs/tickets/${id2}.md"
-
   todo close "${id3}"
-  touch -t 202501010300 "${TODO_TEST_DIR}/docs/tickets/${id3}.md"
+
+  # Set distinct close times
+  local dir="${TODO_TEST_DIR}/docs/tickets"
+  sed -i.bak 's/^closed_at: .*/closed_at: "2025-01-01T01:00:00Z"/' "${dir}/${id1}.md"
+  sed -i.bak 's/^closed_at: .*/closed_at: "2025-01-01T02:00:00Z"/' "${dir}/${id2}.md"
+  sed -i.bak 's/^closed_at: .*/closed_at: "2025-01-01T03:00:00Z"/' "${dir}/${id3}.md"
+  rm -f "${dir}"/*.bak
+
+  # File mtimes in the opposite order must not matter
+  touch -t 202501010300 "${dir}/${id1}.md"
+  touch -t 202501010100 "${dir}/${id3}.md"
 
   run todo closed
   assert_success
@@ -187,3 +192,16 @@ load test_helper
   refute_output --partial "Alice frontend"
   refute_output --partial "Bob backend"
 }
+
+@test "closed: close stamps closed_at" {
+  local out
+  out="$(todo add "Stamped")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo close "${id}"
+
+  run todo show "${id}"
+  assert_output --partial "closed_at:"
+  assert_output --partial "updated:"
+}
@@ -0,0 +1,126 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "config: custom type accepted by add" {
+  cat > .todo.yaml <<'YAML'
+types: [bug, spike]
+YAML
+
+  run todo add "Investigate" -t spike
+  assert_success
+
+  local id
+  id="$(extract_id_from_add "${output}")"
+  run todo show "${id}"
+  assert_output --partial "type: spike"
+}
+
+@test "config: type not in config is rejected" {
+  cat > .todo.yaml <<'YAML'
+types: [bug, spike]
+YAML
+
+  run todo add "Old type" -t task
+  assert_failure
+  assert_output --partial "invalid type"
+  assert_output --partial "bug, spike"
+}
+
+@test "config: defaults apply to add" {
+  cat > .todo.yaml <<'YAML'
+types: [bug, spike]
+defaults:
+  type: spike
+  priority: 1
+  assignee: Bob
+YAML
+
+  run todo add "Defaulted"
+  assert_success
+
+  local id
+  id="$(extract_id_from_add "${output}")"
+  run todo show "${id}"
+  assert_output --partial "type: spike"
+  assert_output --partial "priority: 1"
+  assert_output --partial "assignee: Bob"
+}
+
+@test "config: priority range is enforced" {
+  cat > .todo.yaml <<'YAML'
+priority:
+  min: 1
+  max: 9
+YAML
+
+  run todo add "High number" -p 9
+  assert_success
+
+  run todo add "Too low" -p 0
+  assert_failure
+  assert_output --partial "must be between 1 and 9"
+}
+
+@test "config: custom statuses accepted by status" {
+  cat > .todo.yaml <<'YAML'
+statuses: [open, in_progress, review, closed, wontfix]
+YAML
+
+  local out
+  out="$(todo add "Review me")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo status "${id}" review
+  assert_success
+
+  run todo show "${id}"
+  assert_output --partial "status: review"
+}
+
+@test "config: tickets_dir changes where tickets are stored" {
+  cat > .todo.yaml <<'YAML'
+tickets_dir: tickets
+YAML
+
+  run todo add "Moved"
+  assert_success
+
+  local id
+  id="$(extract_id_from_add "${output}")"
+  [[ -f "tickets/${id}.md" ]]
+  [[ ! -d docs/tickets ]]
+
+  run todo list
+  assert_output --partial "Moved"
+}
+
+@test "config: invalid config fails commands" {
+  echo "types: []" > .todo.yaml
+
+  run todo list
+  assert_failure
+  assert_output --partial "invalid config"
+}
+
+@test "config: invalid config fails commands that only read tickets" {
+  run todo add "Existing"
+  local id
+  id="$(extract_id_from_add "${output}")"
+  echo "types: []" > .todo.yaml
+
+  run todo show "${id}"
+  assert_failure
+  assert_output --partial "invalid config"
+}
+
+@test "config: statuses must include open" {
+  cat > .todo.yaml <<'YAML'
+statuses: [todo, doing, closed]
+YAML
+
+  run todo add "Task"
+  assert_failure
+  assert_output --partial 'statuses must include "open"'
+}
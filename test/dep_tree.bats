@@ -143,3 +143,26 @@ load test_helper
   assert_success
   assert_output --partial "${root_id}"
 }
+
+@test "dep tree --reverse shows the tickets that depend on a ticket" {
+  run todo add "Blocker"
+  local blocker_id
+  blocker_id="$(extract_id_from_add "$output")"
+
+  run todo add "Mid"
+  local mid_id
+  mid_id="$(extract_id_from_add "$output")"
+
+  run todo add "Top"
+  local top_id
+  top_id="$(extract_id_from_add "$output")"
+
+  run todo dep "${mid_id}" "${blocker_id}"
+  run todo dep "${top_id}" "${mid_id}"
+
+  run todo dep tree --reverse "${blocker_id}"
+  assert_success
+  assert_line --index 0 "${blocker_id} [open] Blocker"
+  assert_line --index 1 "└── ${mid_id} [open] Mid"
+  assert_line --index 2 "    └── ${top_id} [open] Top"
+}
@@ -0,0 +1,63 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "doctor: reports no problems for valid tickets" {
+  todo add "Fine"
+
+  run todo doctor
+  assert_success
+  assert_output "No problems found"
+}
+
+@test "doctor: reports each unparseable file with its error" {
+  todo add "Fine"
+  printf 'id: nod\n# No delimiter\n' > docs/tickets/nod.md
+  printf -- '---\nid: [yml\n---\n# Bad YAML\n' > docs/tickets/yml.md
+  printf -- '---\nid: not\n---\nNo title\n' > docs/tickets/not.md
+
+  run todo doctor
+  assert_success
+  assert_line "docs/tickets/nod.md: missing frontmatter opening delimiter"
+  assert_line --partial "docs/tickets/yml.md: invalid frontmatter YAML"
+  assert_line "docs/tickets/not.md: missing title line"
+  assert_line "3 problem(s) found"
+}
+
+@test "doctor: lint is an alias" {
+  mkdir -p docs/tickets
+  printf 'broken\n' > docs/tickets/bad.md
+
+  run todo lint
+  assert_success
+  assert_output --partial "docs/tickets/bad.md: missing frontmatter opening delimiter"
+}
+
+@test "doctor: --strict fails when problems are found" {
+  mkdir -p docs/tickets
+  printf 'broken\n' > docs/tickets/bad.md
+
+  run todo doctor --strict
+  assert_failure
+  assert_output --partial "1 problem(s) found"
+}
+
+@test "doctor: --strict succeeds without problems" {
+  todo add "Fine"
+
+  run todo doctor --strict
+  assert_success
+}
+
+@test "list: warns about unparseable files on stderr" {
+  todo add "Fine"
+  printf 'broken\n' > docs/tickets/bad.md
+
+  run bash -c 'todo list 2>/dev/null'
+  assert_success
+  assert_output --partial "Fine"
+  refute_output --partial "bad.md"
+
+  run bash -c 'todo list 2>&1 >/dev/null'
+  assert_output "warning: docs/tickets/bad.md: missing frontmatter opening delimiter"
+}
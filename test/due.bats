@@ -0,0 +1,77 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "due: groups overdue, today and this week" {
+  run todo add "Late" --due 2020-01-01
+  local late_id
+  late_id="$(extract_id_from_add "$output")"
+
+  run todo add "Now" --due today
+  local now_id
+  now_id="$(extract_id_from_add "$output")"
+
+  run todo add "Soon" --due +3d
+  local soon_id
+  soon_id="$(extract_id_from_add "$output")"
+
+  run todo add "Later" --due +1m
+  run todo add "Whenever"
+
+  run todo due
+  assert_success
+  assert_line --index 0 "Overdue"
+  assert_line --index 1 "  ${late_id} [P2][open][overdue] - Late (due 2020-01-01)"
+  assert_line --index 2 "Today"
+  assert_line --index 3 --partial "  ${now_id} [P2][open] - Now (due "
+  assert_line --index 4 "This week"
+  assert_line --index 5 --partial "  ${soon_id} [P2][open] - Soon (due "
+  refute_output --partial "Later"
+  refute_output --partial "Whenever"
+}
+
+@test "due: rejects invalid dates" {
+  run todo add "Bad" --due someday
+  assert_failure
+  assert_output --partial 'invalid date "someday"'
+}
+
+@test "due: set accepts natural dates and clears them" {
+  run todo add "Task"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo set "${id}" due=2030-05-01 scheduled=2030-04-28
+  assert_success
+
+  run todo show "${id}"
+  assert_output --partial 'due: "2030-05-01"'
+  assert_output --partial 'scheduled: "2030-04-28"'
+
+  run todo set "${id}" due=friday
+  assert_success
+  run todo list has:due
+  assert_output --partial "${id}"
+
+  run todo set "${id}" due=
+  run todo list has:due
+  refute_output --partial "${id}"
+}
+
+@test "ready: marks overdue tickets and sorts by due date with --by-due" {
+  run todo add "Urgent" -p 0
+  local urgent_id
+  urgent_id="$(extract_id_from_add "$output")"
+
+  run todo add "Late" --due 2020-01-01
+  local late_id
+  late_id="$(extract_id_from_add "$output")"
+
+  run todo ready
+  assert_line --index 0 "${urgent_id} [P0][open] - Urgent"
+  assert_line --index 1 "${late_id} [P2][open][overdue] - Late"
+
+  run todo ready --by-due
+  assert_line --index 0 "${late_id} [P2][open][overdue] - Late"
+  assert_line --index 1 "${urgent_id} [P0][open] - Urgent"
+}
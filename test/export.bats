@@ -0,0 +1,76 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "export: writes CSV with a header by default" {
+  todo add "First ticket" -p 1 >/dev/null
+
+  run todo export
+  assert_success
+  assert_line --index 0 "id,title,status,type,priority,assignee,parent,deps,links,tags,external_ref,created,updated,started_at,closed_at,description"
+  assert_output --partial ",First ticket,open,task,1,"
+}
+
+@test "export: filters tickets and includes done ones" {
+  local out
+  out="$(todo add "Done ticket")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+  todo close "${id}" >/dev/null
+  todo add "Open ticket" >/dev/null
+
+  run todo export
+  assert_output --partial "Done ticket"
+
+  run todo export status=closed
+  assert_output --partial "Done ticket"
+  refute_output --partial "Open ticket"
+}
+
+@test "export: --format html writes a self-contained report" {
+  local out
+  out="$(todo add "Report <ticket>")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo export --format html --title "Our backlog" -o report.html
+  assert_success
+
+  run cat report.html
+  assert_output --partial "<title>Our backlog</title>"
+  assert_output --partial "id=\"${id}\""
+  assert_output --partial "Report &lt;ticket&gt;"
+}
+
+@test "export: --format site writes one page per ticket with cross-links" {
+  local out
+  out="$(todo add "Parent")"
+  local parent
+  parent="$(extract_id_from_add "${out}")"
+  out="$(todo add "Child" --parent "${parent}")"
+  local child
+  child="$(extract_id_from_add "${out}")"
+
+  run todo export --format site -o site
+  assert_success
+  assert_output "Exported 2 tickets to site"
+
+  run cat "site/${child}.html"
+  assert_output --partial "href=\"${parent}.html\""
+  assert_output --partial "href=\"index.html\""
+
+  run cat site/index.html
+  assert_output --partial "href=\"${child}.html\""
+}
+
+@test "export: --format site requires --output" {
+  run todo export --format site
+  assert_failure
+  assert_output --partial "--format site requires --output <dir>"
+}
+
+@test "export: rejects unknown formats" {
+  run todo export --format pdf
+  assert_failure
+  assert_output --partial 'unknown export format "pdf"'
+}
@@ -0,0 +1,84 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "format: list renders a template per ticket" {
+  local out
+  out="$(todo add "Fix login" -p 1)"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo list --format '{{.ID}}\t{{.Priority}}\t{{.Title}}'
+  assert_success
+  assert_output "$(printf '%s\t1\tFix login' "${id}")"
+}
+
+@test "format: exposes computed relations" {
+  local out
+  out="$(todo add "Base")"
+  local base
+  base="$(extract_id_from_add "${out}")"
+  out="$(todo add "Dependent")"
+  local dependent
+  dependent="$(extract_id_from_add "${out}")"
+  todo dep "${dependent}" "${base}"
+
+  run todo blocked --format '{{.ID}} {{status .}} <- {{join (ids .Blockers) ","}}'
+  assert_success
+  assert_output "${dependent} open <- ${base}"
+
+  run todo ready --format '{{.Title}} blocks {{len .Blocking}}'
+  assert_success
+  assert_output "Base blocks 1"
+
+  run todo blocked --format '{{range .Blockers}}{{.Title}} is {{status .}}{{end}}'
+  assert_success
+  assert_output "Base is open"
+}
+
+@test "format: show renders a single ticket" {
+  local out
+  out="$(todo add "Parent")"
+  local parent
+  parent="$(extract_id_from_add "${out}")"
+  out="$(todo add "Child" --parent "${parent}")"
+  local child
+  child="$(extract_id_from_add "${out}")"
+
+  run todo show "${child}" --format '{{.Title}} in {{.ParentTicket.Title}}'
+  assert_success
+  assert_output "Child in Parent"
+
+  run todo show "${parent}" --format '{{range .Children}}{{.ID}}{{end}}'
+  assert_success
+  assert_output "${child}"
+}
+
+@test "format: closed supports templates" {
+  local out
+  out="$(todo add "Finished")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+  todo close "${id}"
+
+  run todo closed --format '{{.ID}}:{{.Status}}'
+  assert_success
+  assert_output "${id}:closed"
+}
+
+@test "format: invalid templates fail" {
+  todo add "Ticket"
+
+  run todo list --format '{{.ID'
+  assert_failure
+  assert_output --partial "invalid --format template"
+
+  run todo list --format '{{.Nope}}'
+  assert_failure
+  assert_output --partial "can't evaluate field Nope"
+}
+
+@test "format: cannot be combined with --columns" {
+  run todo list --format '{{.ID}}' --columns id
+  assert_failure
+}
@@ -0,0 +1,74 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "graph: renders deps as Graphviz by default" {
+  local out
+  out="$(todo add "First")"
+  local a
+  a="$(extract_id_from_add "${out}")"
+  out="$(todo add "Second")"
+  local b
+  b="$(extract_id_from_add "${out}")"
+  todo dep "${a}" "${b}" >/dev/null
+
+  run todo graph
+  assert_success
+  assert_line --index 0 "digraph tickets {"
+  assert_output --partial "\"${a}\" -> \"${b}\";"
+  assert_output --partial "label=\"${a} P2 First\""
+}
+
+@test "graph: --format mermaid highlights cycles" {
+  local out
+  out="$(todo add "First")"
+  local a
+  a="$(extract_id_from_add "${out}")"
+  out="$(todo add "Second")"
+  local b
+  b="$(extract_id_from_add "${out}")"
+  todo dep "${a}" "${b}" >/dev/null
+  todo dep "${b}" "${a}" >/dev/null
+
+  run todo graph --format mermaid
+  assert_success
+  assert_line --index 0 "flowchart LR"
+  assert_output --partial "t_${a} --> t_${b}"
+  assert_output --partial "linkStyle 0,1 stroke:#d1242f"
+}
+
+@test "graph: limits the graph to tickets reachable from an ID" {
+  local out
+  out="$(todo add "Parent")"
+  local parent
+  parent="$(extract_id_from_add "${out}")"
+  out="$(todo add "Child" --parent "${parent}")"
+  local child
+  child="$(extract_id_from_add "${out}")"
+  todo add "Unrelated" >/dev/null
+
+  run todo graph "${parent}"
+  assert_success
+  assert_output --partial "\"${parent}\" -> \"${child}\" [style=dashed, arrowhead=empty];"
+  refute_output --partial "Unrelated"
+}
+
+@test "graph: hides done tickets unless --closed" {
+  local out
+  out="$(todo add "Finished")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+  todo close "${id}" >/dev/null
+
+  run todo graph
+  refute_output --partial "Finished"
+
+  run todo graph --closed
+  assert_output --partial "Finished"
+}
+
+@test "graph: rejects unknown formats" {
+  run todo graph --format png
+  assert_failure
+  assert_output --partial 'unknown graph format "png"'
+}
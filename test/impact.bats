@@ -0,0 +1,52 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "impact: shows the tickets closing a ticket would unblock" {
+  run todo add "Blocker"
+  local blocker_id
+  blocker_id="$(extract_id_from_add "$output")"
+
+  run todo add "Waiting" -p 0
+  local waiting_id
+  waiting_id="$(extract_id_from_add "$output")"
+
+  run todo add "Downstream" -p 4
+  local downstream_id
+  downstream_id="$(extract_id_from_add "$output")"
+
+  run todo dep "${waiting_id}" "${blocker_id}"
+  run todo dep "${downstream_id}" "${waiting_id}"
+
+  run todo impact "${blocker_id}"
+  assert_success
+  assert_line --index 0 "Closing ${blocker_id} Blocker unblocks 1 ticket (weight 5)"
+  assert_line --index 1 "  ${waiting_id} [P0][open] - Waiting"
+  assert_line --index 2 "Transitively blocked: 2 tickets (weight 6)"
+}
+
+@test "impact: without an ID ranks blockers" {
+  run todo add "Small blocker"
+  local small_id
+  small_id="$(extract_id_from_add "$output")"
+
+  run todo add "Big blocker"
+  local big_id
+  big_id="$(extract_id_from_add "$output")"
+
+  run todo add "Low" -p 4
+  local low_id
+  low_id="$(extract_id_from_add "$output")"
+
+  run todo add "High" -p 0
+  local high_id
+  high_id="$(extract_id_from_add "$output")"
+
+  run todo dep "${low_id}" "${small_id}"
+  run todo dep "${high_id}" "${big_id}"
+
+  run todo impact
+  assert_success
+  assert_line --index 0 --partial "${big_id} [P2][open] - Big blocker (unblocks 1, weight 5; blocks 1, weight 5)"
+  assert_line --index 1 --partial "${small_id}"
+}
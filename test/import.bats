@@ -0,0 +1,189 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "import: round-trips todo query output" {
+  local out
+  out="$(todo add "Base" -p 1)"
+  local base
+  base="$(extract_id_from_add "${out}")"
+
+  run bash -c "todo query | sed 's/\"priority\":1/\"priority\":3/' | todo import"
+  assert_success
+  assert_output --partial "Updated ${base} Base"
+  assert_output --partial "priority: 1 -> 3"
+  assert_output --partial "0 created, 1 updated, 0 unchanged"
+
+  run todo log "${base}"
+  assert_output --partial "priority: 1 -> 3"
+}
+
+@test "import: creates tickets from a file" {
+  printf '%s\n' '{"id":"abc","title":"Imported","type":"bug","priority":1,"tags":["ui"]}' '{"title":"No id"}' > records.jsonl
+
+  run todo import records.jsonl
+  assert_success
+  assert_output --partial "Created abc Imported"
+  assert_output --partial "2 created, 0 updated, 0 unchanged"
+
+  run todo show abc
+  assert_output --partial "type: bug"
+  assert_output --partial "# Imported"
+}
+
+@test "import: leaves fields missing from a record alone" {
+  local out
+  out="$(todo add "Partial" -t bug -p 3 -a Alice)"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run bash -c "echo '{\"id\":\"${id}\",\"title\":\"Renamed\"}' | todo import"
+  assert_success
+  assert_output --partial "title: Partial -> Renamed"
+  refute_output --partial "type:"
+
+  run todo show "${id}"
+  assert_output --partial "type: bug"
+  assert_output --partial "priority: 3"
+  assert_output --partial "assignee: Alice"
+}
+
+@test "import: --dry-run changes nothing" {
+  local out
+  out="$(todo add "Original")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run bash -c "todo query | sed 's/Original/Renamed/' | todo import --dry-run"
+  assert_success
+  assert_output --partial "Would update ${id} Renamed"
+  assert_output --partial 'title: Original -> Renamed'
+
+  run todo show "${id}"
+  assert_output --partial "# Original"
+}
+
+@test "import: --new-ids remaps references" {
+  printf '%s\n' '{"id":"aaa","title":"Parent"}' '{"id":"bbb","title":"Child","parent":"aaa","deps":["aaa"]}' > records.jsonl
+
+  run todo import --new-ids records.jsonl
+  assert_success
+  assert_output --partial "(was aaa)"
+  assert_output --partial "(was bbb)"
+
+  run todo show aaa
+  assert_failure
+
+  run todo blocked
+  assert_output --partial "Child"
+}
+
+@test "import: rejects invalid records without writing" {
+  printf '%s\n' '{"id":"aaa","title":"Fine"}' '{"id":"bbb","title":"Bad","type":"weird"}' > records.jsonl
+
+  run todo import records.jsonl
+  assert_failure
+  assert_output --partial 'record 2 (bbb): invalid type "weird"'
+
+  run todo list
+  assert_output ""
+
+  run bash -c "echo '{\"title\":\"x\",\"colour\":\"red\"}' | todo import"
+  assert_failure
+  assert_output --partial 'line 1: json: unknown field "colour"'
+}
+
+@test "import: --from github creates tickets and updates them on re-run" {
+  cat > issues.json <<'JSON'
+[{"number":12,"title":"Crash on save","body":"Steps","state":"OPEN","url":"https://github.com/acme/app/issues/12","labels":[{"name":"bug"},{"name":"ui"}],"assignees":[{"login":"alice"}]}]
+JSON
+
+  run todo import --from github issues.json
+  assert_success
+  assert_output --partial "1 created, 0 updated, 0 unchanged"
+
+  run todo query
+  assert_output --partial '"external_ref":"acme/app#12"'
+  assert_output --partial '"type":"bug"'
+  assert_output --partial '"assignee":"alice"'
+  assert_output --partial '"tags":["bug","ui"]'
+
+  sed -i 's/OPEN/CLOSED/' issues.json
+  run todo import --from github issues.json
+  assert_success
+  assert_output --partial "status: open -> closed"
+  assert_output --partial "0 created, 1 updated, 0 unchanged"
+
+  run bash -c "todo query | wc -l"
+  assert_output "1"
+}
+
+@test "import: --from github keeps the local status, type and tags on re-run" {
+  cat > issues.json <<'JSON'
+[{"number":7,"title":"Slow list","body":"","state":"OPEN","url":"https://github.com/acme/app/issues/7","labels":[{"name":"perf"}],"assignees":[]}]
+JSON
+
+  run todo import --from github issues.json
+  assert_success
+  local id
+  id="$(todo query | sed 's/.*"id":"\([^"]*\)".*/\1/')"
+
+  run todo start "${id}"
+  assert_success
+  run todo set "${id}" tags=perf,local type=chore
+  assert_success
+
+  run todo import --from github issues.json
+  assert_success
+  assert_output --partial "0 created, 0 updated, 1 unchanged"
+
+  run todo query
+  assert_output --partial '"status":"in_progress"'
+  assert_output --partial '"type":"chore"'
+  assert_output --partial '"tags":["perf","local"]'
+}
+
+@test "import: --from github skips issues whose ticket is archived" {
+  cat > issues.json <<'JSON'
+[{"number":7,"title":"Slow list","body":"","state":"CLOSED","url":"https://github.com/acme/app/issues/7","labels":[],"assignees":[]}]
+JSON
+
+  run todo import --from github issues.json
+  assert_success
+  run todo archive
+  assert_success
+
+  run todo import --from github issues.json
+  assert_success
+  assert_output --partial "(archived)"
+  assert_output --partial "0 created, 0 updated, 0 unchanged, 1 skipped"
+
+  run todo query
+  assert_output ""
+}
+
+@test "import: --from jira reads a CSV export" {
+  cat > jira.csv <<'CSV'
+Summary,Issue key,Issue Type,Status,Priority,Assignee,Labels,Labels
+Login fails,PROJ-1,Bug,In Progress,High,bob,auth,backend
+CSV
+
+  run todo import --from jira jira.csv
+  assert_success
+  assert_output --partial "Login fails"
+
+  run todo query
+  assert_output --partial '"external_ref":"PROJ-1"'
+  assert_output --partial '"status":"in_progress"'
+  assert_output --partial '"priority":1'
+  assert_output --partial '"tags":["auth","backend"]'
+
+  run todo import --from jira jira.csv
+  assert_output --partial "0 created, 0 updated, 1 unchanged"
+}
+
+@test "import: --from rejects unknown sources" {
+  run todo import --from trello x.json
+  assert_failure
+  assert_output --partial 'unknown import source "trello"'
+}
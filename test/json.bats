@@ -0,0 +1,100 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "json: show --json includes fields and relations" {
+  local out
+  out="$(todo add "Parent")"
+  local parent
+  parent="$(extract_id_from_add "${out}")"
+  out="$(todo add "Child" --parent "${parent}")"
+  local child
+  child="$(extract_id_from_add "${out}")"
+  todo dep "${child}" "${parent}"
+
+  run todo show "${parent}" --json
+  assert_success
+  assert_output --partial "\"id\":\"${parent}\""
+  assert_output --partial "\"title\":\"Parent\""
+  assert_output --partial "\"blocking\":[{\"id\":\"${child}\",\"title\":\"Child\"}]"
+  assert_output --partial "\"children\":[{\"id\":\"${child}\",\"title\":\"Child\"}]"
+  assert_output --partial "\"blockers\":[]"
+
+  run todo show "${child}" --json
+  assert_success
+  assert_output --partial "\"parent_ticket\":{\"id\":\"${parent}\",\"title\":\"Parent\"}"
+  assert_output --partial "\"blockers\":[{\"id\":\"${parent}\",\"title\":\"Parent\"}]"
+}
+
+@test "json: ready, blocked and closed output JSON lines" {
+  local out
+  out="$(todo add "Base")"
+  local base
+  base="$(extract_id_from_add "${out}")"
+  out="$(todo add "Dependent")"
+  local dependent
+  dependent="$(extract_id_from_add "${out}")"
+  todo dep "${dependent}" "${base}"
+  out="$(todo add "Finished")"
+  local finished
+  finished="$(extract_id_from_add "${out}")"
+  todo close "${finished}"
+
+  run todo ready --json
+  assert_success
+  assert_line --index 0 --partial "{\"id\":\"${base}\",\"title\":\"Base\""
+
+  run todo blocked --json
+  assert_success
+  assert_output --partial "\"id\":\"${dependent}\""
+  assert_output --partial "\"blockers\":[\"${base}\"]"
+
+  run todo closed --json
+  assert_success
+  assert_output --partial "\"id\":\"${finished}\""
+  assert_output --partial "\"status\":\"closed\""
+}
+
+@test "json: dep tree --json nests deps with markers" {
+  local out
+  out="$(todo add "Root")"
+  local root
+  root="$(extract_id_from_add "${out}")"
+  out="$(todo add "Child")"
+  local child
+  child="$(extract_id_from_add "${out}")"
+  todo dep "${root}" "${child}"
+  todo dep "${child}" "${root}"
+
+  run todo dep tree "${root}" --json
+  assert_success
+  assert_output "{\"id\":\"${root}\",\"title\":\"Root\",\"deps\":[{\"id\":\"${child}\",\"title\":\"Child\",\"deps\":[{\"id\":\"${root}\",\"title\":\"Root\",\"marker\":\"cycle\",\"deps\":[]}]}]}"
+}
+
+@test "json: dep cycle --json lists cycles" {
+  run todo dep cycle --json
+  assert_success
+  assert_output '{"cycles":[]}'
+
+  local out
+  out="$(todo add "First")"
+  local first
+  first="$(extract_id_from_add "${out}")"
+  out="$(todo add "Second")"
+  local second
+  second="$(extract_id_from_add "${out}")"
+  todo dep "${first}" "${second}"
+  todo dep "${second}" "${first}"
+
+  run todo dep cycle --json
+  assert_success
+  assert_output --partial "{\"cycles\":[{\"ids\":"
+  assert_output --partial "\"tickets\":[{\"id\":"
+  assert_output --partial "\"title\":\"First\""
+  assert_output --partial "\"title\":\"Second\""
+}
+
+@test "json: cannot be combined with --format" {
+  run todo ready --json --format '{{.ID}}'
+  assert_failure
+}
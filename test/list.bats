@@ -265,3 +265,144 @@ load test_helper
   refute_output --partial "Wrong assignee"
   refute_output --partial "Wrong tag"
 }
+
+@test "list: filter expression with OR" {
+  todo add "Urgent fix" -p 0
+  todo add "Tagged docs" -p 3 --tags docs
+  todo add "Other work" -p 3
+
+  run todo list 'priority<=1 OR tag=docs'
+  assert_success
+  assert_output --partial "Urgent fix"
+  assert_output --partial "Tagged docs"
+  refute_output --partial "Other work"
+}
+
+@test "list: filter expression with NOT and parentheses" {
+  todo add "UI bug" -t bug --tags ui
+  todo add "Backend bug" -t bug --tags backend
+  todo add "UI task" -t task --tags ui
+
+  run todo list 'type=bug NOT (tag=backend OR tag=docs)'
+  assert_success
+  assert_output --partial "UI bug"
+  refute_output --partial "Backend bug"
+  refute_output --partial "UI task"
+}
+
+@test "list: filter expression matches title and description text" {
+  todo add "Fix login timeout"
+  todo add "Refactor auth" "Login flow is slow"
+  todo add "Write docs"
+
+  run todo list login
+  assert_success
+  assert_output --partial "Fix login timeout"
+  assert_output --partial "Refactor auth"
+  refute_output --partial "Write docs"
+
+  run todo list title:login
+  assert_success
+  refute_output --partial "Refactor auth"
+}
+
+@test "list: filter expression with has: and dep:" {
+  output=$(todo add "Base")
+  base=$(extract_id_from_add "$output")
+  output=$(todo add "Dependent")
+  dependent=$(extract_id_from_add "$output")
+  todo dep "$dependent" "$base"
+
+  run todo list "dep:$base"
+  assert_success
+  assert_output --partial "Dependent"
+  refute_output --partial "Base"
+
+  run todo list NOT has:deps
+  assert_success
+  assert_output --partial "Base"
+  refute_output --partial "Dependent"
+}
+
+@test "list: filter expression on status shows done tickets" {
+  output=$(todo add "Finished")
+  id=$(extract_id_from_add "$output")
+  todo close "$id"
+  todo add "Pending"
+
+  run todo list 'status=closed OR status=open'
+  assert_success
+  assert_output --partial "Finished"
+  assert_output --partial "Pending"
+}
+
+@test "list: filter expression combines with flags" {
+  todo add "Alice urgent" -a Alice -p 0
+  todo add "Bob urgent" -a Bob -p 0
+
+  run todo list -a Alice 'priority<=1'
+  assert_success
+  assert_output --partial "Alice urgent"
+  refute_output --partial "Bob urgent"
+}
+
+@test "list: invalid filter expression fails" {
+  run todo list 'priority<=high'
+  assert_failure
+  assert_output --partial "expected a number"
+
+  run todo list '(tag=ui'
+  assert_failure
+  assert_output --partial "missing closing parenthesis"
+}
+
+@test "list: --sort orders by fields" {
+  todo add "Low" -p 3
+  todo add "High b" -p 0
+  todo add "High a" -p 0
+
+  run todo list --sort priority,title
+  assert_success
+  assert_line --index 0 --partial "High a"
+  assert_line --index 1 --partial "High b"
+  assert_line --index 2 --partial "Low"
+
+  run todo list --sort -priority
+  assert_success
+  assert_line --index 0 --partial "Low"
+}
+
+@test "list: --sort rejects unknown fields" {
+  run todo list --sort color
+  assert_failure
+  assert_output --partial "unknown sort field \"color\""
+}
+
+@test "list: --limit caps the output" {
+  todo add "First" -p 1
+  todo add "Second" -p 2
+  todo add "Third" -p 3
+
+  run todo list --sort priority --limit 2
+  assert_success
+  assert_output --partial "First"
+  assert_output --partial "Second"
+  refute_output --partial "Third"
+}
+
+@test "list: --columns prints tab-separated fields when not a terminal" {
+  local out
+  out="$(todo add "Fix login" -p 1 -a Alice --tags ui,auth)"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo list --columns id,priority,status,assignee,tags,title
+  assert_success
+  assert_output "$(printf '%s\tP1\topen\tAlice\tui,auth\tFix login' "${id}")"
+}
+
+@test "list: --columns rejects unknown columns" {
+  run todo list --columns id,color
+  assert_failure
+  assert_output --partial "unknown column \"color\""
+}
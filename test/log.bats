@@ -0,0 +1,70 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "log: empty history produces no output" {
+  local out
+  out="$(todo add "Quiet")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo log "${id}"
+  assert_success
+  assert_output ""
+}
+
+@test "log: records status changes with actor" {
+  local out
+  out="$(todo add "Tracked")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo start "${id}"
+  todo close "${id}"
+
+  run todo log "${id}"
+  assert_success
+  assert_line --index 0 --partial "Test User status: open -> in_progress"
+  assert_line --index 1 --partial "Test User status: in_progress -> closed"
+}
+
+@test "log: records deps and links" {
+  local out1 out2
+  out1="$(todo add "Main")"
+  out2="$(todo add "Other")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  todo dep "${id1}" "${id2}"
+  todo link "${id1}" "${id2}"
+  todo undep "${id1}" "${id2}"
+
+  run todo log "${id1}"
+  assert_success
+  assert_output --partial "deps: + ${id2}"
+  assert_output --partial "links: + ${id2}"
+  assert_output --partial "deps: - ${id2}"
+
+  run todo log "${id2}"
+  assert_output --partial "links: + ${id1}"
+}
+
+@test "log: history is stored in frontmatter" {
+  local out
+  out="$(todo add "Stored")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo start "${id}"
+
+  run todo show "${id}"
+  assert_output --partial "history:"
+  assert_output --partial "field: status"
+}
+
+@test "log: fails for nonexistent ticket" {
+  run todo log "ZZZ"
+  assert_failure
+  assert_output --partial "ticket not found"
+}
@@ -0,0 +1,64 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "plan: groups tickets into waves and shows the critical path" {
+  run todo add "Design" --estimate 1d
+  local design_id
+  design_id="$(extract_id_from_add "$output")"
+
+  run todo add "Build" --estimate 2d
+  local build_id
+  build_id="$(extract_id_from_add "$output")"
+
+  run todo add "Docs" --estimate 2h
+  local docs_id
+  docs_id="$(extract_id_from_add "$output")"
+
+  run todo dep "${build_id}" "${design_id}"
+  run todo dep "${docs_id}" "${design_id}"
+
+  run todo plan
+  assert_success
+  assert_line --index 0 "Wave 1 (1 ticket, 1d)"
+  assert_line --index 1 "  ${design_id} [P2][open] - Design (1d)"
+  assert_output --partial "Wave 2 (2 tickets, 2d 2h)"
+  assert_output --partial "Critical path (2 tickets, 3d): ${design_id} -> ${build_id}"
+  assert_output --partial "Total: 3 tickets, 3d 2h"
+}
+
+@test "plan: refuses to plan dependency cycles" {
+  run todo add "First"
+  local a
+  a="$(extract_id_from_add "$output")"
+
+  run todo add "Second"
+  local b
+  b="$(extract_id_from_add "$output")"
+
+  run todo dep "${a}" "${b}"
+  run todo dep "${b}" "${a}"
+
+  run todo plan
+  assert_failure
+  assert_output --partial "can't plan: dependency cycles found"
+}
+
+@test "plan: estimates are validated by add and set" {
+  run todo add "Bad" --estimate 3x
+  assert_failure
+  assert_output --partial 'invalid estimate "3x"'
+
+  run todo add "Good"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo set "${id}" estimate=1.5d
+  assert_success
+
+  run todo show "${id}"
+  assert_output --partial "estimate: 1.5d"
+
+  run todo set "${id}" estimate=soon
+  assert_failure
+}
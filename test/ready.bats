@@ -144,3 +144,15 @@ load test_helper
   assert_output --partial "[open]"
   refute_output --partial "[]"
 }
+
+@test "ready: filter expression narrows ready tickets" {
+  todo add "Low priority" -p 3
+  todo add "High priority" -p 1 --tags ui
+  todo add "High priority backend" -p 1 --tags backend
+
+  run todo ready 'priority<2 NOT tag=backend'
+  assert_success
+  assert_output --partial "High priority"
+  refute_output --partial "Low priority"
+  refute_output --partial "High priority backend"
+}
@@ -0,0 +1,97 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "recur: done creates and links the next occurrence" {
+  run todo add "Rotate certs" --tags ops --due 2020-01-15 --recur monthly
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo done "${id}"
+  assert_success
+  assert_line --index 0 "Completed ticket: Rotate certs"
+  assert_line --index 1 --partial "Next occurrence: "
+
+  local next_id
+  next_id="$(echo "${lines[1]}" | awk '{print $3}')"
+  [ "${next_id}" != "${id}" ]
+
+  run todo show "${next_id}"
+  assert_success
+  assert_output --partial "recur: monthly"
+  assert_output --partial "- ${id}"
+  assert_output --partial "- ops"
+  assert_output --partial "# Rotate certs"
+  refute_output --partial "due: \"2020-"
+
+  run todo show "${id}"
+  assert_output --partial "- ${next_id}"
+}
+
+@test "recur: close also creates the next occurrence" {
+  run todo add "Audit deps" --recur "every 2w"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo close "${id}"
+  assert_success
+  assert_output --partial "Next occurrence: "
+}
+
+@test "recur: status, set and bulk close also create the next occurrence" {
+  run todo add "Audit deps" --recur weekly
+  local first
+  first="$(extract_id_from_add "$output")"
+  run todo add "Rotate certs" --recur monthly
+  local second
+  second="$(extract_id_from_add "$output")"
+  run todo add "Water plants" --recur daily
+  local third
+  third="$(extract_id_from_add "$output")"
+
+  run todo status "${first}" closed
+  assert_success
+  assert_output --partial "Next occurrence: "
+
+  run todo set "${second}" status=closed
+  assert_success
+  assert_output --partial "Next occurrence: "
+
+  run bash -c "echo ${third} | todo bulk --yes close"
+  assert_success
+  assert_output --partial "Next occurrence: "
+
+  run todo list
+  [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "recur: rejects invalid recurrences" {
+  run todo add "Bad" --recur fortnightly
+  assert_failure
+  assert_output --partial 'invalid recurrence "fortnightly"'
+
+  run todo add "Task"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo set "${id}" recur=sometimes
+  assert_failure
+  assert_output --partial 'invalid recurrence "sometimes"'
+}
+
+@test "recur: closing a closed ticket again creates no new occurrence" {
+  run todo add "Audit deps" --recur weekly
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo done "${id}"
+  assert_output --partial "Next occurrence: "
+
+  run todo done "${id}"
+  assert_success
+  refute_output --partial "Next occurrence"
+
+  run todo list
+  assert_line --index 0 --partial "Audit deps"
+  [ "${#lines[@]}" -eq 1 ]
+}
@@ -0,0 +1,76 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "rm: deletes the ticket file" {
+  local out
+  out="$(todo add "Doomed")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo rm "${id}"
+  assert_success
+  assert_output "Removed ${id} Doomed"
+  [ ! -f "docs/tickets/${id}.md" ]
+}
+
+@test "rm: strips deps and links from other tickets" {
+  local out1 out2
+  out1="$(todo add "Doomed")"
+  out2="$(todo add "Survivor")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  todo dep "${id2}" "${id1}"
+  todo link "${id1}" "${id2}"
+
+  run todo rm "${id1}"
+  assert_success
+  assert_line "Updated ${id2}"
+
+  run todo show "${id2}"
+  refute_output --partial "- ${id1}"
+
+  run todo check
+  assert_output "No problems found"
+}
+
+@test "rm: orphans children by default" {
+  local out1 out2
+  out1="$(todo add "Parent")"
+  local pid
+  pid="$(extract_id_from_add "${out1}")"
+  out2="$(todo add "Child" --parent "${pid}")"
+  local cid
+  cid="$(extract_id_from_add "${out2}")"
+
+  todo rm "${pid}"
+
+  run todo show "${cid}"
+  refute_output --partial "parent:"
+}
+
+@test "rm: --reparent moves children to the grandparent" {
+  local out1 out2 out3
+  out1="$(todo add "Top")"
+  local tid
+  tid="$(extract_id_from_add "${out1}")"
+  out2="$(todo add "Middle" --parent "${tid}")"
+  local mid
+  mid="$(extract_id_from_add "${out2}")"
+  out3="$(todo add "Bottom" --parent "${mid}")"
+  local bid
+  bid="$(extract_id_from_add "${out3}")"
+
+  todo rm --reparent "${mid}"
+
+  run todo show "${bid}"
+  assert_output --partial "parent: ${tid} (Top)"
+}
+
+@test "rm: fails for nonexistent ticket" {
+  run todo rm "ZZZ"
+  assert_failure
+  assert_output --partial "ticket not found"
+}
@@ -0,0 +1,83 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "search: finds tickets by title and description" {
+  todo add "Fix login timeout"
+  todo add "Refactor auth" "The login flow is slow"
+  todo add "Write docs"
+
+  run todo search login
+  assert_success
+  assert_output --partial "Fix login timeout"
+  assert_output --partial "Refactor auth"
+  assert_output --partial "description: The login flow is slow"
+  refute_output --partial "Write docs"
+}
+
+@test "search: ranks title matches first" {
+  todo add "Refactor auth" "The login flow is slow"
+  todo add "Fix login timeout"
+
+  run todo search login
+  assert_success
+  assert_line --index 0 --partial "Fix login timeout"
+}
+
+@test "search: searches notes" {
+  local out
+  out="$(todo add "Cleanup")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+  todo add-note "${id}" "Flaky test seen again"
+
+  run todo search flaky
+  assert_success
+  assert_output --partial "Cleanup"
+  assert_output --partial "notes:"
+}
+
+@test "search: all terms must match" {
+  todo add "Login timeout"
+  todo add "Login page"
+
+  run todo search login timeout
+  assert_success
+  assert_output --partial "Login timeout"
+  refute_output --partial "Login page"
+}
+
+@test "search: --regex matches patterns" {
+  todo add "Login time out"
+  todo add "Logout"
+
+  run todo search --regex 'time ?out'
+  assert_success
+  assert_output --partial "Login time out"
+  refute_output --partial "Logout"
+
+  run todo search --regex '('
+  assert_failure
+  assert_output --partial "invalid search pattern"
+}
+
+@test "search: --closed includes done tickets" {
+  local out
+  out="$(todo add "Old login bug")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+  todo close "${id}"
+
+  run todo search login
+  assert_success
+  assert_output ""
+
+  run todo search --closed login
+  assert_success
+  assert_output --partial "Old login bug"
+}
+
+@test "search: requires terms" {
+  run todo search
+  assert_failure
+}
@@ -0,0 +1,154 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "set: changes several fields at once" {
+  local out
+  out="$(todo add "Original")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo set "${id}" priority=1 type=bug assignee=Bob external_ref=JIRA-7
+  assert_success
+  assert_output "Updated ${id} Original"
+
+  run todo show "${id}"
+  assert_output --partial "type: bug"
+  assert_output --partial "priority: 1"
+  assert_output --partial "assignee: Bob"
+  assert_output --partial "external_ref: JIRA-7"
+}
+
+@test "set: changes the title" {
+  local out
+  out="$(todo add "Original")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo set "${id}" title="Renamed ticket"
+  assert_success
+  assert_output "Updated ${id} Renamed ticket"
+
+  run todo list
+  assert_output --partial "Renamed ticket"
+}
+
+@test "set: adds and removes tags" {
+  local out
+  out="$(todo add "Tagged" --tags ui,backend)"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo set "${id}" tags+=perf tags-=ui
+  assert_success
+
+  run todo list --tag perf
+  assert_output --partial "Tagged"
+  run todo list --tag ui
+  refute_output --partial "Tagged"
+  run todo list --tag backend
+  assert_output --partial "Tagged"
+}
+
+@test "set: sets parent with partial ID" {
+  local out1 out2
+  out1="$(todo add "Parent")"
+  out2="$(todo add "Child")"
+  local pid cid
+  pid="$(extract_id_from_add "${out1}")"
+  cid="$(extract_id_from_add "${out2}")"
+
+  run todo set "${cid}" parent="${pid:0:2}"
+  assert_success
+
+  run todo show "${cid}"
+  assert_output --partial "parent: ${pid} (Parent)"
+}
+
+@test "set: updates multiple tickets" {
+  local out1 out2
+  out1="$(todo add "First")"
+  out2="$(todo add "Second")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  run todo set "${id1}" "${id2}" assignee=Carol
+  assert_success
+  assert_line "Updated ${id1} First"
+  assert_line "Updated ${id2} Second"
+
+  run todo list --assignee Carol
+  assert_output --partial "First"
+  assert_output --partial "Second"
+}
+
+@test "set: records changes in history" {
+  local out
+  out="$(todo add "Tracked")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo set "${id}" priority=0
+
+  run todo log "${id}"
+  assert_output --partial "priority: 2 -> 0"
+}
+
+@test "set: rejects invalid values without modifying any ticket" {
+  local out1 out2
+  out1="$(todo add "First")"
+  out2="$(todo add "Second")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  run todo set "${id1}" "${id2}" assignee=Dan priority=9
+  assert_failure
+  assert_output --partial "invalid priority 9"
+
+  run todo list --assignee Dan
+  assert_output ""
+}
+
+@test "set: rejects invalid type" {
+  local out
+  out="$(todo add "Typed")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo set "${id}" type=nope
+  assert_failure
+  assert_output --partial "invalid type \"nope\""
+}
+
+@test "set: rejects unknown fields" {
+  local out
+  out="$(todo add "Ticket")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo set "${id}" created=yesterday
+  assert_failure
+  assert_output --partial "unknown field \"created\""
+}
+
+@test "set: fails for nonexistent ticket" {
+  run todo set ZZZ priority=1
+  assert_failure
+  assert_output --partial "ticket not found"
+}
+
+@test "set: reports no changes and leaves the ticket alone" {
+  local out
+  out="$(todo add "Same" -p 2)"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo set "${id}" priority=2 status=open
+  assert_success
+  assert_output "No changes"
+
+  run todo show "${id}"
+  refute_output --partial "updated:"
+}
@@ -0,0 +1,84 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+write_bug_template() {
+  mkdir -p docs/tickets/templates
+  cat > docs/tickets/templates/bug.md <<'TEMPLATE'
+---
+priority: 1
+tags: [bug]
+acceptance: Regression test for {{.Title}}
+---
+## Steps to reproduce
+
+## Expected
+
+## Actual
+TEMPLATE
+}
+
+@test "template: add -t uses the template named after the type" {
+  write_bug_template
+
+  run todo add "Login crash" -t bug --tags ui
+  assert_success
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo show "${id}"
+  assert_output --partial "priority: 1"
+  assert_output --partial "acceptance: Regression test for Login crash"
+  assert_output --partial "- ui"
+  assert_output --partial "- bug"
+  assert_output --partial "## Steps to reproduce"
+}
+
+@test "template: flags and descriptions win over the template" {
+  write_bug_template
+
+  run todo add "Typo" -t bug -p 3 -d "Just a typo"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo show "${id}"
+  assert_output --partial "priority: 3"
+  assert_output --partial "Just a typo"
+  refute_output --partial "## Steps to reproduce"
+}
+
+@test "template: --template picks a named template" {
+  mkdir -p docs/tickets/templates
+  cat > docs/tickets/templates/incident.md <<'TEMPLATE'
+---
+type: bug
+tags: [incident]
+---
+Incident on {{.Date}}: {{.Title}}
+TEMPLATE
+
+  run todo add "Outage" --template incident
+  assert_success
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo show "${id}"
+  assert_output --partial "type: bug"
+  assert_output --partial "- incident"
+  assert_output --regexp "Incident on [0-9]{4}-[0-9]{2}-[0-9]{2}: Outage"
+
+  run todo add "Missing" --template nope
+  assert_failure
+  assert_output --partial "template not found: nope"
+}
+
+@test "template: templates are not listed as tickets" {
+  write_bug_template
+
+  run todo list
+  assert_success
+  assert_output ""
+
+  run todo check
+  assert_success
+}
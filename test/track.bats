@@ -0,0 +1,75 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+@test "track: start and stop record a session and the spent time" {
+  run todo add "Fix login"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo track start "${id}"
+  assert_success
+  assert_output "Started timer for ${id} Fix login"
+
+  run todo track start "${id}"
+  assert_failure
+  assert_output --partial "timer already running for ${id}"
+
+  run todo track stop "${id}"
+  assert_success
+  assert_output --partial "Stopped timer for ${id} Fix login"
+
+  run todo show "${id}"
+  assert_output --partial "spent: "
+  assert_output --partial "sessions:"
+
+  run todo track stop "${id}"
+  assert_failure
+  assert_output --partial "no timer running for ${id}"
+}
+
+@test "track: start --track starts a timer" {
+  run todo add "Tracked"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo start --track "${id}"
+  assert_success
+  assert_output --partial "Started timer for ${id}"
+
+  run todo track stop "${id}"
+  assert_success
+}
+
+@test "track: tracking.auto_start starts a timer on start" {
+  cat > .todo.yaml <<'YAML'
+tracking:
+  auto_start: true
+YAML
+
+  run todo add "Auto"
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo start "${id}"
+  assert_success
+  assert_output --partial "Started timer for ${id}"
+}
+
+@test "report time: groups spent time" {
+  run todo add "Login" --tags ui
+  local id
+  id="$(extract_id_from_add "$output")"
+
+  run todo track start "${id}"
+  run todo track stop "${id}"
+
+  run todo report time --by tag --since 2000-01-01
+  assert_success
+  assert_line --index 0 --regexp "^ui +0h +1 ticket$"
+  assert_line --index 1 --regexp "^Total +0h$"
+
+  run todo report time --by status
+  assert_failure
+  assert_output --partial 'invalid grouping "status"'
+}
@@ -0,0 +1,102 @@
+#!/usr/bin/env bats
+
+load test_helper
+
+setup_workflow() {
+  cat > .todo.yaml <<'YAML'
+statuses: [open, in_progress, review, closed, wontfix]
+done_statuses: [closed, wontfix]
+transitions:
+  open: [in_progress, wontfix]
+  in_progress: [open, review]
+  review: [in_progress, closed]
+  closed: [open]
+  wontfix: [open]
+YAML
+}
+
+@test "workflow: allowed transition succeeds" {
+  setup_workflow
+  local out
+  out="$(todo add "Flow")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo start "${id}"
+  assert_success
+
+  run todo status "${id}" review
+  assert_success
+}
+
+@test "workflow: forbidden transition lists allowed next states" {
+  setup_workflow
+  local out
+  out="$(todo add "Flow")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo close "${id}"
+  assert_failure
+  assert_output --partial 'cannot change status from "open" to "closed"'
+  assert_output --partial "allowed: in_progress, wontfix"
+
+  run todo show "${id}"
+  refute_output --partial "status: closed"
+}
+
+@test "workflow: done statuses hidden from list" {
+  setup_workflow
+  local out
+  out="$(todo add "Abandoned")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  todo status "${id}" wontfix
+
+  run todo list
+  refute_output --partial "Abandoned"
+
+  run todo closed
+  assert_output --partial "Abandoned"
+}
+
+@test "workflow: done status dep does not block ready" {
+  setup_workflow
+  local out1 out2
+  out1="$(todo add "Main")"
+  out2="$(todo add "Dep")"
+  local id1 id2
+  id1="$(extract_id_from_add "${out1}")"
+  id2="$(extract_id_from_add "${out2}")"
+
+  todo dep "${id1}" "${id2}"
+
+  run todo blocked
+  assert_output --partial "Main"
+
+  todo status "${id2}" wontfix
+
+  run todo ready
+  assert_output --partial "Main"
+
+  run todo blocked
+  refute_output --partial "Main"
+}
+
+@test "workflow: done uses first done status" {
+  cat > .todo.yaml <<'YAML'
+statuses: [open, done]
+done_statuses: [done]
+YAML
+  local out
+  out="$(todo add "Finish")"
+  local id
+  id="$(extract_id_from_add "${out}")"
+
+  run todo done "${id}"
+  assert_success
+
+  run todo show "${id}"
+  assert_output --partial "status: done"
+}